@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/gandarez/changelog-action/pkg/git"
@@ -15,15 +16,24 @@ type gitClient interface {
 	LatestTagOrHash() string
 	PreviousTag(tag string) (string, error)
 	TagExists(tag string) bool
-	Log(refs ...string) (string, error)
+	IsHead(ref string) (bool, error)
+	Tags() ([]string, error)
+	RemoteURL() (string, error)
+	Run(args ...string) (string, error)
+	Log(refs []string, paths ...string) ([]git.Commit, error)
 }
 
-func Run() (string, error) {
-	params, err := LoadParams()
-	if err != nil {
-		return "", fmt.Errorf("failed to load parameters: %s", err)
-	}
+// Result holds the generated changelog, the computed next version and whether there are breaking changes.
+type Result struct {
+	Changelog          string
+	NextVersion        string
+	HasBreakingChanges bool
+	// Summary is the job summary, set when enabled.
+	Summary string
+}
 
+// Run generates the changelog for the repository in params.
+func Run(params Params) (Result, error) {
 	if params.Debug {
 		log.SetLevel(log.DebugLevel)
 		log.Debug("debug logs enabled\n")
@@ -32,18 +42,68 @@ func Run() (string, error) {
 	log.Debug(params.String())
 
 	git := git.NewGit(params.RepoDir)
+	git.TagPattern = params.tagGlob()
 
 	return Changelog(params, git)
 }
 
-func Changelog(params Params, gc gitClient) (string, error) {
-	err := gc.MakeSafe()
+func Changelog(params Params, gc gitClient) (Result, error) {
+	if err := checkRepo(gc); err != nil {
+		return Result{}, err
+	}
+
+	remote := resolveRemote(params, gc)
+
+	if params.FullHistory {
+		releases, err := fullHistory(params, gc, remote)
+		if err != nil {
+			return Result{}, err
+		}
+
+		if len(releases) > 0 {
+			if err := nextVersionSinceTag(params, gc, &releases[len(releases)-1]); err != nil {
+				return Result{}, err
+			}
+		}
+
+		return render(params, releases...)
+	}
+
+	previousTag, tag, err := resolveRange(params, gc)
+	if err != nil {
+		return Result{}, err
+	}
+
+	release, err := collectRelease(params, gc, remote, previousTag, tag)
 	if err != nil {
-		return "", fmt.Errorf("failed to make safe: %s", err)
+		return Result{}, err
+	}
+
+	if err := nextVersionSinceTag(params, gc, &release); err != nil {
+		return Result{}, err
+	}
+
+	return render(params, release)
+}
+
+// checkRepo makes the repository safe and checks it's a git repository.
+func checkRepo(gc gitClient) error {
+	if err := gc.MakeSafe(); err != nil {
+		return fmt.Errorf("failed to make safe: %s", err)
 	}
 
 	if !gc.IsRepo() {
-		return "", fmt.Errorf("current folder is not a git repository")
+		return fmt.Errorf("current folder is not a git repository")
+	}
+
+	return nil
+}
+
+// resolveRange returns the previous and current tags of the release, auto detecting them when not set.
+// On pull requests they are the base and head commits.
+func resolveRange(params Params, gc gitClient) (string, string, error) {
+	if params.BaseSHA != "" && params.HeadSHA != "" {
+		return params.BaseSHA, params.HeadSHA, nil
 	}
 
 	var tag = params.CurrentTag
@@ -52,65 +112,378 @@ func Changelog(params Params, gc gitClient) (string, error) {
 		tag = gc.LatestTagOrHash()
 	}
 
-	var refs = []string{fmt.Sprintf("%s..%s", params.PreviousTag, tag)}
+	var previousTag = params.PreviousTag
 
 	// If previous tag is not provided or does not exist, get the previous tag and may result in a commit hash.
 	if params.PreviousTag == "" || !gc.TagExists(params.PreviousTag) {
-		previousTag, err := gc.PreviousTag(tag)
+		var err error
+
+		previousTag, err = resolvePreviousTag(params, gc, tag)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get previous tag: %s", err)
+		}
+	}
+
+	return previousTag, tag, nil
+}
+
+// logRange returns the git log range of a release. When previousTag is empty every commit
+// reachable from tag is included.
+func logRange(previousTag, tag string) string {
+	if previousTag == "" {
+		return tag
+	}
+
+	return fmt.Sprintf("%s..%s", previousTag, tag)
+}
+
+// resolveRemote returns the remote used to link commits and releases. The repo url takes
+// precedence over the origin remote and the repo provider over the provider detected from
+// the host name. It returns nil if no remote can be resolved.
+func resolveRemote(params Params, gc gitClient) *git.Remote {
+	remote := parseRemote(params, gc)
+	if remote != nil && params.RepoProvider != "" {
+		remote.Provider = git.Provider(params.RepoProvider)
+	}
+
+	return remote
+}
+
+func parseRemote(params Params, gc gitClient) *git.Remote {
+	if params.RepoURL != "" {
+		remote, err := git.ParseRepoURL(params.RepoURL)
 		if err != nil {
-			return "", fmt.Errorf("failed to get previous tag: %s", err)
+			log.Warnf("failed to parse repo url: %s", err)
+			return nil
 		}
 
-		refs = []string{fmt.Sprintf("%s..%s", previousTag, tag)}
+		return &remote
 	}
 
-	log, err := gc.Log(refs...)
+	remoteURL, err := gc.RemoteURL()
+	if err != nil || remoteURL == "" {
+		log.Debugf("no origin remote found, links are disabled")
+		return nil
+	}
+
+	remote, err := git.ParseRemote(remoteURL)
+	if err != nil {
+		log.Debugf("failed to parse origin remote, links are disabled: %s", err)
+		return nil
+	}
+
+	return &remote
+}
+
+// collectRelease collects the commits between previousTag and tag. When previousTag is empty
+// every commit reachable from tag is collected. Commits and the release range are linked when
+// remote is set.
+func collectRelease(params Params, gc gitClient, remote *git.Remote, previousTag, tag string) (Release, error) {
+	var refs = []string{logRange(previousTag, tag)}
+
+	gitCommits, err := gc.Log(refs, pathspecs(params.Paths, params.ExcludePaths)...)
+	if err != nil {
+		return Release{}, fmt.Errorf("failed to get log: %s", err)
+	}
+
+	// The release date is the date of its newest commit.
+	var date = time.Now().UTC()
+
+	if len(gitCommits) > 0 {
+		date = gitCommits[0].Date
+	}
+
+	commits, err := applyFilters(params.commitFilters(), parseCommits(gitCommits))
 	if err != nil {
-		return "", fmt.Errorf("failed to get log: %s", err)
+		return Release{}, err
 	}
 
-	var entries = strings.Split(log, "\n")
-	entries = entries[0 : len(entries)-1]
+	if remote != nil {
+		for i := range commits {
+			commits[i].URL = remote.CommitURL(commits[i].Hash)
+		}
+	}
 
-	entries, err = filterEntries(params.Exclude, entries)
+	patterns, err := issuePatterns(params.IssuePatterns, remote)
 	if err != nil {
-		return "", err
+		return Release{}, err
+	}
+
+	linkIssues(patterns, commits)
+
+	// The breaking changes and the next version account for every commit, including the grouped
+	// and collapsed ones.
+	var filtered = commits
+
+	var dependencies []Dependency
+
+	if params.GroupDependencies {
+		commits, dependencies = groupDependencies(commits)
+	}
+
+	var dependencyUpdates []Commit
+
+	if params.CollapseAuthors {
+		commits, dependencyUpdates, err = collapseAuthors(params.ExcludeAuthors, commits)
+		if err != nil {
+			return Release{}, err
+		}
+	}
+
+	release := Release{
+		Version:           tag,
+		PreviousVersion:   previousTag,
+		Range:             refs[0],
+		Date:              date,
+		Commits:           commits,
+		Issues:            releaseIssues(commits),
+		Dependencies:      dependencies,
+		DependencyUpdates: dependencyUpdates,
+		BreakingChanges:   breakingChanges(filtered),
+		Groups:            groupCommits(params.sections(), commits),
+	}
+
+	release.HasBreakingChanges = len(release.BreakingChanges) > 0
+
+	if params.GoModDiff && previousTag != "" {
+		release.GoModules, err = goModDiffs(gc, params.GoModFiles, params.Paths, previousTag, tag)
+		if err != nil {
+			return Release{}, err
+		}
+	}
+
+	if remote != nil && previousTag != "" {
+		release.CompareURL = remote.CompareURL(previousTag, tag)
 	}
 
-	changelogElements := []string{
-		"## Changelog",
-		strings.Join(entries, "\n"),
+	// The base commit of a pull request is not a version to bump.
+	if params.NextVersion && params.BaseSHA == "" {
+		release.NextVersion = nextVersion(params.versionPrefix(), previousTag, tag, filtered)
 	}
 
-	return strings.Join(changelogElements, "\n\n"), nil
+	return release, nil
 }
 
-func filterEntries(filters []string, entries []string) ([]string, error) {
-	for _, filter := range filters {
-		r, err := regexp.Compile(filter)
+// nextVersionSinceTag sets the next version of a release whose tag doesn't point at HEAD, e.g.
+// the latest tag found by git describe when HEAD is not tagged. That version is already released,
+// so the next one is the tag bumped with the commits since. A tag not created yet is kept as is.
+func nextVersionSinceTag(params Params, gc gitClient, release *Release) error {
+	if !params.NextVersion || params.BaseSHA != "" {
+		return nil
+	}
+
+	isHead, err := gc.IsHead(release.Version)
+	if err != nil {
+		log.Debugf("failed to check if %s points at HEAD: %s", release.Version, err)
+
+		return nil
+	}
+
+	if isHead {
+		return nil
+	}
+
+	gitCommits, err := gc.Log([]string{release.Version + "..HEAD"}, pathspecs(params.Paths, params.ExcludePaths)...)
+	if err != nil {
+		return fmt.Errorf("failed to get log since %s: %s", release.Version, err)
+	}
+
+	commits, err := applyFilters(params.commitFilters(), parseCommits(gitCommits))
+	if err != nil {
+		return err
+	}
+
+	release.NextVersion = nextVersion(params.versionPrefix(), release.Version, release.Version, commits)
+
+	return nil
+}
+
+// render renders the releases, given from oldest to newest, and writes them to the changelog file if set.
+func render(params Params, releases ...Release) (Result, error) {
+	r, err := newRenderer(params)
+	if err != nil {
+		return Result{}, err
+	}
+
+	output, err := renderReleases(r, releases, params.FullHistory)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// A pull request preview is not a release, so the changelog file is left untouched.
+	if params.ChangelogFile != "" && params.BaseSHA == "" {
+		if err := writeChangelogFile(params, releases...); err != nil {
+			return Result{}, fmt.Errorf("failed to write changelog file: %s", err)
+		}
+	}
+
+	result := Result{Changelog: output}
+
+	if params.Summary {
+		result.Summary, err = jobSummary(params, releases...)
 		if err != nil {
-			return entries, err
+			return Result{}, fmt.Errorf("failed to render summary: %s", err)
 		}
+	}
+
+	if len(releases) > 0 {
+		result.NextVersion = releases[len(releases)-1].NextVersion
+	}
 
-		entries = remove(r, entries)
+	for _, release := range releases {
+		result.HasBreakingChanges = result.HasBreakingChanges || release.HasBreakingChanges
 	}
 
-	return entries, nil
+	return result, nil
 }
 
-func remove(filter *regexp.Regexp, entries []string) []string {
+// pathspecs returns the git pathspecs limiting the log to paths and excluding excludePaths.
+func pathspecs(paths, excludePaths []string) []string {
 	var result []string
 
-	for _, entry := range entries {
-		if !filter.MatchString(extractCommitInfo(entry)) {
-			result = append(result, entry)
-		}
+	result = append(result, paths...)
+
+	for _, p := range excludePaths {
+		result = append(result, ":(exclude)"+p)
 	}
 
 	return result
 }
 
-// extractCommitInfo removes first word which is the commit hash.
-func extractCommitInfo(line string) string {
-	return strings.Join(strings.Split(line, " ")[1:], " ")
+// commitFilter keeps the commits having a value matching any of the include filters, if any,
+// then removes the commits having a value matching any of the exclude filters.
+type commitFilter struct {
+	include []string
+	exclude []string
+	// exact also matches filters literally, e.g. `dependabot[bot]`.
+	exact  bool
+	values func(commit Commit) []string
+}
+
+// matcher reports whether a commit value matches a filter.
+type matcher func(value string) bool
+
+// commitFilters returns the filters of the commit header, author and body. Excluded authors
+// are left to be collapsed when collapse authors is set.
+func (p Params) commitFilters() []commitFilter {
+	excludeAuthors := p.ExcludeAuthors
+
+	if p.CollapseAuthors {
+		excludeAuthors = nil
+	}
+
+	return []commitFilter{
+		{include: p.Include, exclude: p.Exclude, values: headerValues},
+		{include: p.IncludeAuthors, exclude: excludeAuthors, exact: true, values: authorValues},
+		{include: p.IncludeBody, exclude: p.ExcludeBody, values: bodyValues},
+	}
+}
+
+func headerValues(commit Commit) []string {
+	return []string{commit.Header}
+}
+
+func authorValues(commit Commit) []string {
+	return []string{commit.Author, commit.AuthorEmail}
+}
+
+func bodyValues(commit Commit) []string {
+	return []string{commit.Body}
+}
+
+// applyFilters applies the filters in order. Includes are evaluated before excludes.
+func applyFilters(filters []commitFilter, commits []Commit) ([]Commit, error) {
+	for _, f := range filters {
+		if len(f.include) > 0 {
+			include, err := newMatchers(f.include, f.exact)
+			if err != nil {
+				return nil, err
+			}
+
+			commits, _ = partition(include, f.values, commits)
+		}
+
+		exclude, err := newMatchers(f.exclude, f.exact)
+		if err != nil {
+			return nil, err
+		}
+
+		_, commits = partition(exclude, f.values, commits)
+	}
+
+	return commits, nil
+}
+
+// collapseAuthors splits the commits of the given authors, matched exactly or as regexp against
+// the author name and email, from the other commits. Breaking changes are never collapsed.
+func collapseAuthors(authors []string, commits []Commit) ([]Commit, []Commit, error) {
+	matchers, err := newMatchers(authors, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	collapsed, rest := partition(matchers, func(commit Commit) []string {
+		if commit.Breaking {
+			return nil
+		}
+
+		return authorValues(commit)
+	}, commits)
+
+	return rest, collapsed, nil
+}
+
+// newMatchers returns the matchers of the filters. With exact, a value equal to a filter matches
+// too and a filter not being a valid regexp is only matched exactly.
+func newMatchers(filters []string, exact bool) ([]matcher, error) {
+	matchers := make([]matcher, 0, len(filters))
+
+	for _, filter := range filters {
+		r, err := regexp.Compile(filter)
+
+		switch {
+		case err != nil && !exact:
+			return nil, err
+		case err != nil:
+			matchers = append(matchers, func(value string) bool {
+				return strings.EqualFold(value, filter)
+			})
+		case exact:
+			matchers = append(matchers, func(value string) bool {
+				return strings.EqualFold(value, filter) || r.MatchString(value)
+			})
+		default:
+			matchers = append(matchers, r.MatchString)
+		}
+	}
+
+	return matchers, nil
+}
+
+// partition splits the commits having a value matched by any of the matchers from the others.
+func partition(matchers []matcher, values func(Commit) []string, commits []Commit) ([]Commit, []Commit) {
+	var matched, rest []Commit
+
+	for _, commit := range commits {
+		if matchAny(matchers, values(commit)) {
+			matched = append(matched, commit)
+			continue
+		}
+
+		rest = append(rest, commit)
+	}
+
+	return matched, rest
+}
+
+func matchAny(matchers []matcher, values []string) bool {
+	for _, match := range matchers {
+		for _, value := range values {
+			if match(value) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
@@ -2,33 +2,252 @@ package changelog
 
 import (
 	"testing"
+	"time"
+
+	"github.com/gandarez/changelog-action/pkg/git"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestFilterEntries(t *testing.T) {
+func TestExcludeCommits(t *testing.T) {
 	filters := []string{
 		"^Merge pull request .*",
 		"Fix .*",
 	}
 
-	entries := []string{
-		"2b982db Fix logging",
-		"5a359bb Add git ignore",
-		"55df180 Merge pull request #10 from author/bugfix/on_release",
+	commits := []Commit{
+		{ShortHash: "2b982db", Header: "Fix logging", Subject: "Fix logging"},
+		{ShortHash: "5a359bb", Header: "Add git ignore", Subject: "Add git ignore"},
+		{ShortHash: "55df180", Header: "Merge pull request #10 from author/bugfix/on_release"},
+		{ShortHash: "8b9c0d1", Header: "fix: Fix crash", Type: "fix", Subject: "Fix crash"},
+	}
+
+	filtered, err := applyFilters([]commitFilter{{exclude: filters, values: headerValues}}, commits)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Commit{commits[1]}, filtered)
+}
+
+func TestExcludeCommitsErr(t *testing.T) {
+	_, err := applyFilters([]commitFilter{{exclude: []string{"^(feat"}, values: headerValues}}, nil)
+
+	assert.EqualError(t, err, "error parsing regexp: missing closing ): `^(feat`")
+}
+
+func TestApplyFilters(t *testing.T) {
+	commits := []Commit{
+		{ShortHash: "2b982db", Header: "feat: add users endpoint", Author: "John Doe", AuthorEmail: "john@example.com"},
+		{ShortHash: "5a359bb", Header: "fix: handle empty tag", Author: "Jane Doe", AuthorEmail: "jane@example.com"},
+		{ShortHash: "55df180", Header: "docs: update readme", Author: "John Doe", AuthorEmail: "john@example.com"},
+		{ShortHash: "8b9c0d1", Header: "fix: typo", Author: "John Doe", AuthorEmail: "john@example.com", Body: "[skip changelog]"},
+		{ShortHash: "0a1b2c3", Header: "perf: cache templates", Author: "bot", AuthorEmail: "bot@example.com"},
+	}
+
+	tests := map[string]struct {
+		Filters  []commitFilter
+		Expected []Commit
+	}{
+		"include header": {
+			Filters:  Params{Include: []string{"^(feat|fix|perf)"}}.commitFilters(),
+			Expected: []Commit{commits[0], commits[1], commits[3], commits[4]},
+		},
+		"include before exclude": {
+			Filters:  Params{Include: []string{"^(feat|fix)"}, Exclude: []string{"^fix: typo"}}.commitFilters(),
+			Expected: []Commit{commits[0], commits[1]},
+		},
+		"include authors by email": {
+			Filters:  Params{IncludeAuthors: []string{"@example.com$"}, ExcludeAuthors: []string{"^bot"}}.commitFilters(),
+			Expected: commits[:4],
+		},
+		"exclude authors by name": {
+			Filters:  Params{ExcludeAuthors: []string{"^John"}}.commitFilters(),
+			Expected: []Commit{commits[1], commits[4]},
+		},
+		"body": {
+			Filters:  Params{ExcludeBody: []string{`\[skip changelog\]`}}.commitFilters(),
+			Expected: []Commit{commits[0], commits[1], commits[2], commits[4]},
+		},
+		"include body": {
+			Filters:  Params{IncludeBody: []string{"skip"}}.commitFilters(),
+			Expected: []Commit{commits[3]},
+		},
 	}
 
-	filtered, err := filterEntries(filters, entries)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filtered, err := applyFilters(test.Filters, commits)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, filtered)
+		})
+	}
+}
+
+func TestApplyFilters_ExactAuthors(t *testing.T) {
+	commits := []Commit{
+		{ShortHash: "2b982db", Author: "dependabot[bot]", AuthorEmail: "49699333+dependabot[bot]@users.noreply.github.com"},
+		{ShortHash: "5a359bb", Author: "renovate[bot]", AuthorEmail: "29139614+renovate[bot]@users.noreply.github.com"},
+		{ShortHash: "55df180", Author: "dependabo", AuthorEmail: "dependabo@example.com"},
+		{ShortHash: "8b9c0d1", Author: "John Doe", AuthorEmail: "john@example.com"},
+	}
+
+	filters := Params{ExcludeAuthors: []string{"dependabot[bot]", "^renovate\\[bot\\]$", "renovate[bot"}}.commitFilters()
+
+	filtered, err := applyFilters(filters, commits)
 	require.NoError(t, err)
 
-	assert.Equal(t, []string{
-		"5a359bb Add git ignore",
-	}, filtered)
+	assert.Equal(t, []Commit{commits[2], commits[3]}, filtered)
 }
 
-func TestExtractCommitInfo(t *testing.T) {
-	result := extractCommitInfo("55df180 Merge pull request #10 from author/bugfix/on_release")
+func TestCollapseAuthors(t *testing.T) {
+	commits := []Commit{
+		{ShortHash: "2b982db", Author: "dependabot[bot]"},
+		{ShortHash: "5a359bb", Author: "John Doe", AuthorEmail: "john@example.com"},
+		{ShortHash: "55df180", Author: "renovate[bot]"},
+	}
+
+	rest, collapsed, err := collapseAuthors([]string{`\[bot\]$`}, commits)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Commit{commits[1]}, rest)
+	assert.Equal(t, []Commit{commits[0], commits[2]}, collapsed)
+}
+
+func TestApplyFiltersErr(t *testing.T) {
+	_, err := applyFilters(Params{IncludeBody: []string{"^(feat"}}.commitFilters(), nil)
+
+	assert.EqualError(t, err, "error parsing regexp: missing closing ): `^(feat`")
+}
+
+func TestParseCommit(t *testing.T) {
+	date := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		Subject  string
+		Expected Commit
+	}{
+		"conventional commit": {
+			Subject: "feat: add template support",
+			Expected: Commit{
+				Type:    "feat",
+				Subject: "add template support",
+			},
+		},
+		"conventional commit with scope": {
+			Subject: "fix(git): handle missing tags",
+			Expected: Commit{
+				Type:    "fix",
+				Scope:   "git",
+				Subject: "handle missing tags",
+			},
+		},
+		"breaking change": {
+			Subject: "Feat(api)!: drop v1 endpoints",
+			Expected: Commit{
+				Type:     "feat",
+				Scope:    "api",
+				Subject:  "drop v1 endpoints",
+				Breaking: true,
+			},
+		},
+		"non conventional commit": {
+			Subject: "Merge pull request #10 from author/bugfix/on_release",
+			Expected: Commit{
+				Subject: "Merge pull request #10 from author/bugfix/on_release",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			commit := parseCommit(git.Commit{
+				Hash:        "2b982db6c4e4a1b9e3f0c7b2a8d1e5f4a3b2c1d0",
+				ShortHash:   "2b982db",
+				AuthorName:  "John Doe",
+				AuthorEmail: "john@example.com",
+				Date:        date,
+				Subject:     test.Subject,
+				Body:        "Some details.",
+				Parents:     []string{"5a359bb8e1f0a2b3c4d5e6f708192a3b4c5d6e7f"},
+			})
+
+			expected := test.Expected
+			expected.Hash = "2b982db6c4e4a1b9e3f0c7b2a8d1e5f4a3b2c1d0"
+			expected.ShortHash = "2b982db"
+			expected.Author = "John Doe"
+			expected.AuthorEmail = "john@example.com"
+			expected.Date = date
+			expected.Header = test.Subject
+			expected.Body = "Some details."
+			expected.Parents = []string{"5a359bb8e1f0a2b3c4d5e6f708192a3b4c5d6e7f"}
+
+			assert.Equal(t, expected, commit)
+		})
+	}
+}
+
+func TestParseCommit_BreakingChangeFooter(t *testing.T) {
+	commit := parseCommit(git.Commit{
+		Subject: "feat(api): rename users endpoint",
+		Body:    "Some details.\n\nBREAKING CHANGE: /users is now /accounts.\nUpdate your clients.\n\nRefs: #12",
+	})
+
+	assert.True(t, commit.Breaking)
+	assert.Equal(t, "/users is now /accounts.\nUpdate your clients.", commit.BreakingChange)
+}
+
+func TestParseBreakingChange(t *testing.T) {
+	tests := map[string]struct {
+		Body     string
+		Expected string
+		Found    bool
+	}{
+		"no footer": {
+			Body: "Some details.",
+		},
+		"space": {
+			Body:     "BREAKING CHANGE: config file is required",
+			Expected: "config file is required",
+			Found:    true,
+		},
+		"hyphen": {
+			Body:     "Some details.\n\nBREAKING-CHANGE: config file is required",
+			Expected: "config file is required",
+			Found:    true,
+		},
+		"multiline until next footer": {
+			Body:     "BREAKING CHANGE: config file is required.\n\nMove your inputs to it.\nReviewed-by: Jane\nRefs #3",
+			Expected: "config file is required.\n\nMove your inputs to it.",
+			Found:    true,
+		},
+		"not a footer": {
+			Body: "This is not a BREAKING CHANGE: at all",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			notes, found := parseBreakingChange(test.Body)
+
+			assert.Equal(t, test.Found, found)
+			assert.Equal(t, test.Expected, notes)
+		})
+	}
+}
+
+func TestGroupCommits(t *testing.T) {
+	commits := []Commit{
+		{Hash: "2b982db", Type: "fix", Subject: "handle missing tags"},
+		{Hash: "5a359bb", Type: "chore", Subject: "bump deps"},
+		{Hash: "55df180", Type: "feat", Subject: "add template support"},
+	}
+
+	groups := groupCommits(defaultSections(), commits)
 
-	assert.Equal(t, "Merge pull request #10 from author/bugfix/on_release", result)
+	assert.Equal(t, []Group{
+		{Title: "Features", Commits: []Commit{commits[2]}},
+		{Title: "Bug Fixes", Commits: []Commit{commits[0]}},
+		{Title: "Others", Commits: []Commit{commits[1]}},
+	}, groups)
 }
@@ -2,9 +2,17 @@ package changelog_test
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gandarez/changelog-action/cmd/changelog"
+	"github.com/gandarez/changelog-action/pkg/git"
+	"github.com/gandarez/changelog-action/pkg/semver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,23 +28,26 @@ func TestChangelog(t *testing.T) {
 		"no previous tag": {
 			PreviousTag: "e63c125b28842b17546cc92f635d7eccc8e909a7",
 			Expected: "## Changelog\n\n" +
-				"2b982db First commit",
+				"### Others\n\n" +
+				"- 2b982db First commit",
 		},
 		"auto": {
 			LatestTagOrHash: "v0.2.0",
 			PreviousTag:     "v0.1.0",
 			Expected: "## Changelog\n\n" +
-				"2b982db First commit\n" +
-				"5a359bb Second commit\n" +
-				"1774db0 Merge pull request #1 from author/feature/feat-1",
+				"### Others\n\n" +
+				"- 2b982db First commit\n" +
+				"- 5a359bb Second commit\n" +
+				"- 1774db0 Merge pull request #1 from author/feature/feat-1",
 		},
 		"auto and latest tag is hash": {
 			LatestTagOrHash: "e63c125b28842b17546cc92f635d7eccc8e909a7",
 			PreviousTag:     "53db8447314a82e42e801568a085d424a739260a",
 			Expected: "## Changelog\n\n" +
-				"2b982db First commit\n" +
-				"5a359bb Second commit\n" +
-				"1774db0 Merge pull request #1 from author/feature/feat-1",
+				"### Others\n\n" +
+				"- 2b982db First commit\n" +
+				"- 5a359bb Second commit\n" +
+				"- 1774db0 Merge pull request #1 from author/feature/feat-1",
 		},
 		"current tag set": {
 			LatestTagOrHash: "",
@@ -46,8 +57,9 @@ func TestChangelog(t *testing.T) {
 				CurrentTag: "v0.3.0",
 			},
 			Expected: "## Changelog\n\n" +
-				"5a359bb Second commit\n" +
-				"c57f56f Third commit",
+				"### Others\n\n" +
+				"- 5a359bb Second commit\n" +
+				"- c57f56f Third commit",
 		},
 		"current tag and previous tag set": {
 			LatestTagOrHash: "",
@@ -58,9 +70,10 @@ func TestChangelog(t *testing.T) {
 				PreviousTag: "v0.1.0",
 			},
 			Expected: "## Changelog\n\n" +
-				"2b982db First commit\n" +
-				"5a359bb Second commit\n" +
-				"c57f56f Third commit",
+				"### Others\n\n" +
+				"- 2b982db First commit\n" +
+				"- 5a359bb Second commit\n" +
+				"- c57f56f Third commit",
 		},
 		"current tag set hash with previous tag set but does not exist": {
 			LatestTagOrHash: "",
@@ -74,8 +87,9 @@ func TestChangelog(t *testing.T) {
 				},
 			},
 			Expected: "## Changelog\n\n" +
-				"2b982db First commit\n" +
-				"5a359bb Second commit",
+				"### Others\n\n" +
+				"- 2b982db First commit\n" +
+				"- 5a359bb Second commit",
 		},
 		"auto and exclude": {
 			LatestTagOrHash: "v0.2.0",
@@ -86,8 +100,24 @@ func TestChangelog(t *testing.T) {
 				},
 			},
 			Expected: "## Changelog\n\n" +
-				"2b982db First commit\n" +
-				"5a359bb Second commit",
+				"### Others\n\n" +
+				"- 2b982db First commit\n" +
+				"- 5a359bb Second commit",
+		},
+		"conventional commits": {
+			LatestTagOrHash: "v0.4.0",
+			PreviousTag:     "v0.3.0",
+			Expected: "## Changelog\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 **api:** add users endpoint\n" +
+				"- 4e5f6a7 support config file\n\n" +
+				"### Bug Fixes\n\n" +
+				"- 8b9c0d1 **cli:** handle empty tag\n\n" +
+				"### Performance\n\n" +
+				"- 2a3b4c5 cache parsed templates\n\n" +
+				"### Others\n\n" +
+				"- 6d7e8f9 update readme\n" +
+				"- 0a1b2c3 Merge pull request #2 from author/feature/feat-2",
 		},
 	}
 
@@ -102,7 +132,879 @@ func TestChangelog(t *testing.T) {
 			result, err := changelog.Changelog(test.Params, gc)
 			require.NoError(t, err)
 
-			assert.Equal(t, test.Expected, result)
+			assert.Equal(t, test.Expected, result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_Format(t *testing.T) {
+	tests := map[string]struct {
+		Format   string
+		Expected string
+	}{
+		"json": {
+			Format: changelog.FormatJSON,
+			Expected: `{
+  "version": "v0.2.0",
+  "previous_version": "v0.1.0",
+  "range": "v0.1.0..v0.2.0",
+  "date": "2024-01-02T15:04:05Z",
+  "has_breaking_changes": false,
+  "commits": [
+    {
+      "hash": "2b982db000000000000000000000000000000000",
+      "short_hash": "2b982db",
+      "author": "John Doe",
+      "author_email": "john@example.com",
+      "date": "2024-01-02T15:04:05Z",
+      "header": "First commit",
+      "type": "",
+      "scope": "",
+      "subject": "First commit",
+      "body": "",
+      "breaking": false,
+      "breaking_change": "",
+      "parents": null
+    }
+  ]
+}`,
+		},
+		"text": {
+			Format: changelog.FormatText,
+			Expected: "Changelog\n\n" +
+				"Others\n" +
+				"  2b982db First commit",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+			gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+				return gitCommits("2b982db First commit"), nil
+			}
+
+			result, err := changelog.Changelog(changelog.Params{Format: test.Format}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_Template(t *testing.T) {
+	tmplFile := filepath.Join(t.TempDir(), "changelog.tmpl")
+
+	err := os.WriteFile(tmplFile, []byte(`# {{ .Version | trimPrefix "v" }}{{ range .Groups }}
+{{ .Title | upper }}{{ range .Commits }}
+* {{ .Subject }} ({{ shortHash .Hash }}){{ end }}{{ end }}`), 0600)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		Template string
+		Expected string
+	}{
+		"inline": {
+			Template: `{{ .PreviousVersion }}...{{ .Version }}: {{ range .Commits }}{{ .Subject | lower }};{{ end }}`,
+			Expected: "v0.1.0...v0.2.0: add users endpoint;first commit;",
+		},
+		"file": {
+			Template: tmplFile,
+			Expected: "# 0.2.0\n" +
+				"FEATURES\n" +
+				"* add users endpoint (9f1c2d3)\n" +
+				"OTHERS\n" +
+				"* First commit (2b982db)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+			gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+				return gitCommits(
+					"9f1c2d3 Feat: add users endpoint",
+					"2b982db First commit",
+				), nil
+			}
+
+			result, err := changelog.Changelog(changelog.Params{Template: test.Template}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_TemplateErr(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+
+	_, err := changelog.Changelog(changelog.Params{Template: "{{ .Unknown }"}, gc)
+
+	assert.EqualError(t, err, "failed to parse template: template: changelog:1: unexpected \"}\" in operand")
+}
+
+func TestChangelog_NextVersion(t *testing.T) {
+	tests := map[string]struct {
+		LatestTag   string
+		PreviousTag string
+		IsHead      bool
+		Log         []git.Commit
+		Expected    string
+	}{
+		"breaking change": {
+			LatestTag:   "v1.2.3",
+			PreviousTag: "v1.2.2",
+			Log:         gitCommits("9f1c2d3 feat(api)!: drop v1 endpoints", "8b9c0d1 fix: handle empty tag"),
+			Expected:    "v2.0.0",
+		},
+		"feature": {
+			LatestTag:   "v1.2.3",
+			PreviousTag: "v1.2.2",
+			Log:         gitCommits("9f1c2d3 feat: add users endpoint", "8b9c0d1 fix: handle empty tag"),
+			Expected:    "v1.3.0",
+		},
+		"fix": {
+			LatestTag:   "1.2.3",
+			PreviousTag: "1.2.2",
+			Log:         gitCommits("8b9c0d1 fix: handle empty tag"),
+			Expected:    "1.2.4",
+		},
+		"breaking change footer": {
+			LatestTag:   "v1.2.3",
+			PreviousTag: "v1.2.2",
+			Log: []git.Commit{{
+				ShortHash: "9f1c2d3",
+				Subject:   "feat(api): rename users endpoint",
+				Body:      "BREAKING-CHANGE: /users is now /accounts",
+			}},
+			Expected: "v2.0.0",
+		},
+		"no commits since latest tag": {
+			LatestTag:   "v1.2.3",
+			PreviousTag: "v1.2.2",
+		},
+		"tagged head": {
+			LatestTag:   "v1.3.0",
+			PreviousTag: "v1.2.3",
+			IsHead:      true,
+			Log:         gitCommits("9f1c2d3 feat: add users endpoint"),
+			Expected:    "v1.3.0",
+		},
+		"first release": {
+			LatestTag:   "5a359bb000000000000000000000000000000000",
+			PreviousTag: "e63c125b28842b17546cc92f635d7eccc8e909a7",
+			IsHead:      true,
+			Log:         gitCommits("9f1c2d3 feat: add users endpoint"),
+			Expected:    "0.1.0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock(test.LatestTag, test.PreviousTag, false)
+			gc.IsHeadFn = func(ref string) (bool, error) {
+				assert.Equal(t, test.LatestTag, ref)
+
+				return test.IsHead, nil
+			}
+			gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+				switch {
+				case refs[0] == test.LatestTag+"..HEAD":
+					return test.Log, nil
+				case refs[0] == test.PreviousTag+".."+test.LatestTag && test.IsHead:
+					return test.Log, nil
+				case refs[0] == test.PreviousTag+".."+test.LatestTag:
+					// The commits of the latest tag, already released.
+					return gitCommits("1774db0 fix: released fix"), nil
+				default:
+					return nil, fmt.Errorf("unexpected range %s", refs[0])
+				}
+			}
+
+			result, err := changelog.Changelog(changelog.Params{NextVersion: true}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.NextVersion)
+
+			current, err := semver.Parse(strings.TrimPrefix(test.LatestTag, "v"))
+			if err != nil || test.Expected == "" {
+				return
+			}
+
+			next, err := semver.Parse(strings.TrimPrefix(result.NextVersion, "v"))
+			require.NoError(t, err)
+
+			if test.IsHead {
+				assert.Zero(t, semver.Compare(next, current), "a tagged HEAD is the next version")
+			} else {
+				assert.Positive(t, semver.Compare(next, current), "next version must be greater than the latest tag")
+			}
+		})
+	}
+}
+
+func TestChangelog_BreakingChanges(t *testing.T) {
+	log := gitCommits("9f1c2d3 feat(api)!: drop v1 endpoints", "8b9c0d1 fix: handle empty tag")
+	log[1].Body = "BREAKING CHANGE: empty tags are rejected.\nSet current_tag explicitly."
+
+	tests := map[string]struct {
+		Format   string
+		Expected string
+	}{
+		"markdown": {
+			Format: changelog.FormatMarkdown,
+			Expected: "## Changelog\n\n" +
+				"### Breaking Changes\n\n" +
+				"- 9f1c2d3 **api:** drop v1 endpoints\n" +
+				"- 8b9c0d1 handle empty tag\n\n" +
+				"  empty tags are rejected.\n" +
+				"  Set current_tag explicitly.\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 **api:** drop v1 endpoints\n\n" +
+				"### Bug Fixes\n\n" +
+				"- 8b9c0d1 handle empty tag",
+		},
+		"text": {
+			Format: changelog.FormatText,
+			Expected: "Changelog\n\n" +
+				"Breaking Changes\n" +
+				"  9f1c2d3 api: drop v1 endpoints\n" +
+				"  8b9c0d1 handle empty tag\n" +
+				"    empty tags are rejected.\n" +
+				"    Set current_tag explicitly.\n\n" +
+				"Features\n" +
+				"  9f1c2d3 api: drop v1 endpoints\n\n" +
+				"Bug Fixes\n" +
+				"  8b9c0d1 handle empty tag",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+			gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+				return log, nil
+			}
+
+			result, err := changelog.Changelog(changelog.Params{Format: test.Format}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Changelog)
+			assert.True(t, result.HasBreakingChanges)
+		})
+	}
+}
+
+func TestChangelog_NoBreakingChanges(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+
+	result, err := changelog.Changelog(changelog.Params{}, gc)
+	require.NoError(t, err)
+
+	assert.False(t, result.HasBreakingChanges)
+	assert.NotContains(t, result.Changelog, "Breaking Changes")
+}
+
+func TestChangelog_PullRequest(t *testing.T) {
+	gc := initGitClientMock("v0.3.0", "v0.2.0", true)
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		assert.Equal(t, []string{"6dcb09b..e5bd391"}, refs)
+
+		return gitCommits("c57f56f feat: add preview"), nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		CurrentTag:  "v0.3.0",
+		BaseSHA:     "6dcb09b",
+		HeadSHA:     "e5bd391",
+		NextVersion: true,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n\n### Features\n\n- c57f56f add preview", result.Changelog)
+	assert.Empty(t, result.NextVersion)
+	assert.Zero(t, gc.LatestTagOrHashFnInvoked)
+	assert.Zero(t, gc.PreviousTagFnInvoked)
+}
+
+func TestChangelog_Links(t *testing.T) {
+	tests := map[string]struct {
+		RemoteURL string
+		Params    changelog.Params
+		Expected  string
+	}{
+		"github remote": {
+			RemoteURL: "git@github.com:author/repo.git",
+			Expected: "## Changelog\n\n" +
+				"### Others\n\n" +
+				"- [5a359bb](https://github.com/author/repo/commit/5a359bb000000000000000000000000000000000) Second commit\n" +
+				"- [c57f56f](https://github.com/author/repo/commit/c57f56f000000000000000000000000000000000) Third commit\n\n" +
+				"**Full Changelog**: [v0.2.0...v0.3.0](https://github.com/author/repo/compare/v0.2.0...v0.3.0)",
+		},
+		"gitlab remote": {
+			RemoteURL: "https://gitlab.com/group/repo.git",
+			Expected: "## Changelog\n\n" +
+				"### Others\n\n" +
+				"- [5a359bb](https://gitlab.com/group/repo/-/commit/5a359bb000000000000000000000000000000000) Second commit\n" +
+				"- [c57f56f](https://gitlab.com/group/repo/-/commit/c57f56f000000000000000000000000000000000) Third commit\n\n" +
+				"**Full Changelog**: [v0.2.0...v0.3.0](https://gitlab.com/group/repo/-/compare/v0.2.0...v0.3.0)",
+		},
+		"repo url": {
+			RemoteURL: "git@github.com:author/repo.git",
+			Params:    changelog.Params{RepoURL: "https://git.example.com/team/repo"},
+			Expected: "## Changelog\n\n" +
+				"### Others\n\n" +
+				"- [5a359bb](https://git.example.com/team/repo/commit/5a359bb000000000000000000000000000000000) Second commit\n" +
+				"- [c57f56f](https://git.example.com/team/repo/commit/c57f56f000000000000000000000000000000000) Third commit\n\n" +
+				"**Full Changelog**: [v0.2.0...v0.3.0](https://git.example.com/team/repo/compare/v0.2.0...v0.3.0)",
+		},
+		"repo provider": {
+			RemoteURL: "git@code.example.com:group/repo.git",
+			Params:    changelog.Params{RepoProvider: "gitlab"},
+			Expected: "## Changelog\n\n" +
+				"### Others\n\n" +
+				"- [5a359bb](https://code.example.com/group/repo/-/commit/5a359bb000000000000000000000000000000000) Second commit\n" +
+				"- [c57f56f](https://code.example.com/group/repo/-/commit/c57f56f000000000000000000000000000000000) Third commit\n\n" +
+				"**Full Changelog**: [v0.2.0...v0.3.0](https://code.example.com/group/repo/-/compare/v0.2.0...v0.3.0)",
+		},
+		"repo url and provider": {
+			RemoteURL: "git@github.com:author/repo.git",
+			Params:    changelog.Params{RepoURL: "https://code.example.com/team/repo", RepoProvider: "bitbucket"},
+			Expected: "## Changelog\n\n" +
+				"### Others\n\n" +
+				"- [5a359bb](https://code.example.com/team/repo/commits/5a359bb000000000000000000000000000000000) Second commit\n" +
+				"- [c57f56f](https://code.example.com/team/repo/commits/c57f56f000000000000000000000000000000000) Third commit\n\n" +
+				"**Full Changelog**: [v0.2.0...v0.3.0](https://code.example.com/team/repo/branches/compare/v0.3.0%0Dv0.2.0)",
+		},
+		"text": {
+			RemoteURL: "git@github.com:author/repo.git",
+			Params:    changelog.Params{Format: changelog.FormatText},
+			Expected: "Changelog\n\n" +
+				"Others\n" +
+				"  5a359bb Second commit\n" +
+				"  c57f56f Third commit\n\n" +
+				"Full Changelog: https://github.com/author/repo/compare/v0.2.0...v0.3.0",
+		},
+		"unknown remote": {
+			RemoteURL: "/path/to/repo",
+			Expected: "## Changelog\n\n" +
+				"### Others\n\n" +
+				"- 5a359bb Second commit\n" +
+				"- c57f56f Third commit",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("v0.3.0", "v0.2.0", false)
+			gc.RemoteURLFn = func() (string, error) {
+				return test.RemoteURL, nil
+			}
+
+			result, err := changelog.Changelog(test.Params, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_Issues(t *testing.T) {
+	log := gitCommits("9f1c2d3 fix: crash on start (#123)", "8b9c0d1 feat: export as csv")
+	log[1].Body = "Fixes JIRA-456, refs #123."
+
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.RemoteURLFn = func() (string, error) {
+		return "git@github.com:author/repo.git", nil
+	}
+	gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+		return log, nil
+	}
+
+	params := changelog.Params{
+		IssuePatterns: []string{`\b(?P<ref>JIRA-\d+)\b => https://jira.example.com/browse/${ref}`},
+	}
+
+	result, err := changelog.Changelog(params, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n\n"+
+		"### Features\n\n"+
+		"- [8b9c0d1](https://github.com/author/repo/commit/8b9c0d1000000000000000000000000000000000) export as csv\n\n"+
+		"### Bug Fixes\n\n"+
+		"- [9f1c2d3](https://github.com/author/repo/commit/9f1c2d3000000000000000000000000000000000) "+
+		"crash on start ([#123](https://github.com/author/repo/issues/123))\n\n"+
+		"**Full Changelog**: [v0.1.0...v0.2.0](https://github.com/author/repo/compare/v0.1.0...v0.2.0)",
+		result.Changelog)
+
+	params.Template = "{{ range .Issues }}{{ .Reference }} {{ .URL }}\n{{ end }}"
+
+	result, err = changelog.Changelog(params, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "#123 https://github.com/author/repo/issues/123\n"+
+		"JIRA-456 https://jira.example.com/browse/JIRA-456\n", result.Changelog)
+}
+
+func TestChangelog_Groups(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+		return gitCommits(
+			"9f1c2d3 feat: add users endpoint",
+			"8b9c0d1 fix: handle empty tag",
+			"6d7e8f9 sec: escape user input",
+			"2b982db docs: update readme",
+		), nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		Groups: []changelog.GroupConfig{
+			{Title: "Security", Types: []string{"sec"}},
+			{Title: "Changes", Types: []string{"feat", "fix"}},
+		},
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n\n"+
+		"### Security\n\n"+
+		"- 6d7e8f9 escape user input\n\n"+
+		"### Changes\n\n"+
+		"- 9f1c2d3 add users endpoint\n"+
+		"- 8b9c0d1 handle empty tag", result.Changelog)
+}
+
+func TestChangelog_Summary(t *testing.T) {
+	tests := map[string]struct {
+		Params   changelog.Params
+		Expected string
+	}{
+		"disabled": {},
+		"markdown": {
+			Params: changelog.Params{Summary: true},
+			Expected: "## Changelog\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 add users endpoint\n\n" +
+				"### Others\n\n" +
+				"- 2b982db First commit",
+		},
+		"json with statistics": {
+			Params: changelog.Params{Summary: true, SummaryStatistics: true, Format: changelog.FormatJSON},
+			Expected: "## Changelog\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 add users endpoint\n\n" +
+				"### Others\n\n" +
+				"- 2b982db First commit\n\n" +
+				"### Statistics\n\n" +
+				"| | Count |\n" +
+				"| --- | ---: |\n" +
+				"| Commits | 2 |\n" +
+				"| Contributors | 1 |\n" +
+				"| Breaking changes | 0 |\n" +
+				"| Features | 1 |\n" +
+				"| Others | 1 |",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+			gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+				return gitCommits(
+					"9f1c2d3 feat: add users endpoint",
+					"2b982db First commit",
+				), nil
+			}
+
+			result, err := changelog.Changelog(test.Params, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Summary)
+		})
+	}
+}
+
+func TestChangelog_CollapseAuthors(t *testing.T) {
+	log := gitCommits(
+		"9f1c2d3 feat: add users endpoint",
+		"8b9c0d1 chore(deps): bump golang.org/x/net from 0.1.0 to 0.2.0",
+		"6d7e8f9 chore(deps): update module github.com/apex/log to v1.9.1",
+		"4e5f6a7 chore(deps): bump golang.org/x/sys from 0.1.0 to 0.2.0",
+	)
+	log[1].AuthorName = "dependabot[bot]"
+	log[2].AuthorName = "renovate[bot]"
+	log[3].AuthorName = "dependabot[bot]"
+
+	tests := map[string]struct {
+		Params   changelog.Params
+		Expected string
+	}{
+		"exclude": {
+			Params: changelog.Params{
+				ExcludeAuthors: []string{"dependabot[bot]", "renovate[bot]"},
+			},
+			Expected: "## Changelog\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 add users endpoint",
+		},
+		"collapse markdown": {
+			Params: changelog.Params{
+				ExcludeAuthors:  []string{"dependabot[bot]", "renovate[bot]"},
+				CollapseAuthors: true,
+			},
+			Expected: "## Changelog\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 add users endpoint\n\n" +
+				"**Dependency updates**: 3 commits by dependabot[bot], renovate[bot]",
+		},
+		"collapse text": {
+			Params: changelog.Params{
+				Format:          changelog.FormatText,
+				ExcludeAuthors:  []string{"renovate[bot]"},
+				CollapseAuthors: true,
+			},
+			Expected: "Changelog\n\n" +
+				"Features\n" +
+				"  9f1c2d3 add users endpoint\n\n" +
+				"Others\n" +
+				"  8b9c0d1 deps: bump golang.org/x/net from 0.1.0 to 0.2.0\n" +
+				"  4e5f6a7 deps: bump golang.org/x/sys from 0.1.0 to 0.2.0\n\n" +
+				"Dependency updates: 1 commit by renovate[bot]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+			gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+				return log, nil
+			}
+
+			result, err := changelog.Changelog(test.Params, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_CollapseAuthorsBreakingChange(t *testing.T) {
+	log := gitCommits(
+		"9f1c2d3 feat!: drop node 16",
+		"8b9c0d1 chore(deps): update module github.com/apex/log to v1.9.1",
+	)
+	log[0].AuthorName = "renovate[bot]"
+	log[1].AuthorName = "renovate[bot]"
+
+	gc := initGitClientMock("v1.2.0", "v1.1.0", false)
+	gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+		return log, nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		ExcludeAuthors:  []string{"renovate[bot]"},
+		CollapseAuthors: true,
+		NextVersion:     true,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n\n"+
+		"### Breaking Changes\n\n"+
+		"- 9f1c2d3 drop node 16\n\n"+
+		"### Features\n\n"+
+		"- 9f1c2d3 drop node 16\n\n"+
+		"**Dependency updates**: 1 commit by renovate[bot]", result.Changelog)
+	assert.True(t, result.HasBreakingChanges)
+	assert.Equal(t, "v2.0.0", result.NextVersion)
+}
+
+func TestChangelog_GroupDependencies(t *testing.T) {
+	log := gitCommits(
+		"9f1c2d3 chore(deps): bump golang.org/x/net from 0.2.0 to 0.3.0",
+		"8b9c0d1 feat: add users endpoint",
+		"6d7e8f9 chore(deps): bump golang.org/x/sys from 0.1.0 to 0.2.0",
+		"4e5f6a7 chore(deps): bump golang.org/x/net from 0.1.0 to 0.2.0",
+	)
+
+	breaking := gitCommits("9f1c2d3 chore(deps)!: bump github.com/x/y from 1.0.0 to 2.0.0")
+	breaking[0].Body = "BREAKING CHANGE: y v2 drops the legacy API."
+
+	tests := map[string]struct {
+		Log              []git.Commit
+		Format           string
+		Expected         string
+		ExpectedBreaking bool
+	}{
+		"breaking bump": {
+			Log:    breaking,
+			Format: changelog.FormatMarkdown,
+			Expected: "## Changelog\n\n" +
+				"### Breaking Changes\n\n" +
+				"- 9f1c2d3 **deps:** bump github.com/x/y from 1.0.0 to 2.0.0\n\n" +
+				"  y v2 drops the legacy API.\n\n" +
+				"### Dependencies\n\n" +
+				"| Package | From | To |\n" +
+				"| --- | --- | --- |\n" +
+				"| github.com/x/y | 1.0.0 | 2.0.0 |",
+			ExpectedBreaking: true,
+		},
+		"markdown": {
+			Log:    log,
+			Format: changelog.FormatMarkdown,
+			Expected: "## Changelog\n\n" +
+				"### Features\n\n" +
+				"- 8b9c0d1 add users endpoint\n\n" +
+				"### Dependencies\n\n" +
+				"| Package | From | To |\n" +
+				"| --- | --- | --- |\n" +
+				"| golang.org/x/net | 0.1.0 | 0.3.0 |\n" +
+				"| golang.org/x/sys | 0.1.0 | 0.2.0 |",
+		},
+		"text": {
+			Log:    log,
+			Format: changelog.FormatText,
+			Expected: "Changelog\n\n" +
+				"Features\n" +
+				"  8b9c0d1 add users endpoint\n\n" +
+				"Dependencies\n" +
+				"  golang.org/x/net 0.1.0 -> 0.3.0\n" +
+				"  golang.org/x/sys 0.1.0 -> 0.2.0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+			gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+				return test.Log, nil
+			}
+
+			result, err := changelog.Changelog(changelog.Params{
+				Format:            test.Format,
+				GroupDependencies: true,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Changelog)
+			assert.Equal(t, test.ExpectedBreaking, result.HasBreakingChanges)
+		})
+	}
+}
+
+func TestChangelog_GroupDependenciesNextVersion(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+		return gitCommits("9f1c2d3 chore(deps): bump golang.org/x/net from 0.2.0 to 0.3.0"), nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{GroupDependencies: true, NextVersion: true}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.1.1", result.NextVersion)
+}
+
+func TestChangelog_GoModDiff(t *testing.T) {
+	files := map[string]string{
+		"v0.1.0:go.mod":              "module example.com/app\n\ngo 1.22\n\nrequire golang.org/x/net v0.20.0\n",
+		"v0.2.0:go.mod":              "module example.com/app\n\ngo 1.23\n\nrequire golang.org/x/net v0.21.0\n",
+		"v0.1.0:tools/go.mod":        "module example.com/tools\n\ngo 1.22\n",
+		"v0.2.0:tools/go.mod":        "module example.com/tools\n\ngo 1.22\n\nrequire golang.org/x/tools v0.1.0\n",
+		"v0.1.0:services/api/go.mod": "module example.com/api\n\ngo 1.22\n",
+		"v0.2.0:services/api/go.mod": "module example.com/api\n\ngo 1.22\n",
+	}
+
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.RunFn = func(args ...string) (string, error) {
+		switch args[0] {
+		case "ls-tree":
+			assert.Equal(t, []string{"ls-tree", "-r", "--name-only", "v0.2.0"}, args)
+
+			return "README.md\ngo.mod\ngo.sum\nservices/api/go.mod\ntools/go.mod\n", nil
+		case "show":
+			if content, ok := files[args[1]]; ok {
+				return content, nil
+			}
+
+			return "", errors.New("fatal: path does not exist")
+		}
+
+		return "", errors.New("unexpected command")
+	}
+
+	tests := map[string]struct {
+		Params   changelog.Params
+		Expected string
+	}{
+		"markdown": {
+			Params: changelog.Params{GoModDiff: true},
+			Expected: "## Changelog\n\n" +
+				"### Others\n\n" +
+				"- 2b982db First commit\n" +
+				"- 5a359bb Second commit\n" +
+				"- 1774db0 Merge pull request #1 from author/feature/feat-1\n\n" +
+				"### Go Modules\n\n" +
+				"**go.mod**\n\n" +
+				"- go: 1.22 → 1.23\n" +
+				"- Upgraded golang.org/x/net v0.20.0 → v0.21.0\n\n" +
+				"**tools/go.mod**\n\n" +
+				"- Added golang.org/x/tools v0.1.0",
+		},
+		"text with files": {
+			Params: changelog.Params{
+				Format:     changelog.FormatText,
+				GoModDiff:  true,
+				GoModFiles: []string{"go.mod"},
+			},
+			Expected: "Changelog\n\n" +
+				"Others\n" +
+				"  2b982db First commit\n" +
+				"  5a359bb Second commit\n" +
+				"  1774db0 Merge pull request #1 from author/feature/feat-1\n\n" +
+				"Go Modules\n" +
+				"  go: 1.22 → 1.23\n" +
+				"  Upgraded golang.org/x/net v0.20.0 → v0.21.0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := changelog.Changelog(test.Params, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_GoModDiffErr(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.RunFn = func(_ ...string) (string, error) {
+		return "", errors.New("fatal: not a valid object name v0.2.0")
+	}
+
+	_, err := changelog.Changelog(changelog.Params{GoModDiff: true}, gc)
+
+	assert.EqualError(t, err, "failed to list files of v0.2.0: fatal: not a valid object name v0.2.0")
+}
+
+func TestChangelog_Paths(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.LogFn = func(refs []string, paths ...string) ([]git.Commit, error) {
+		assert.Equal(t, []string{"v0.1.0..v0.2.0"}, refs)
+		assert.Equal(t, []string{"services/api", ":(exclude)services/api/docs"}, paths)
+
+		return gitCommits(
+			"9f1c2d3 feat(api): add users endpoint",
+			"1774db0 Merge pull request #1 from author/feature/feat-1",
+		), nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		Paths:        []string{"services/api"},
+		ExcludePaths: []string{"services/api/docs"},
+		Exclude:      []string{"^Merge pull request .*"},
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n\n"+
+		"### Features\n\n"+
+		"- 9f1c2d3 **api:** add users endpoint", result.Changelog)
+}
+
+func TestChangelog_NextVersionTagPrefix(t *testing.T) {
+	gc := initGitClientMock("api/v1.4.0", "api/v1.3.2", false)
+	gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+		return gitCommits("9f1c2d3 feat: add users endpoint"), nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		TagPrefix:   "api/",
+		NextVersion: true,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "api/v1.4.0", result.NextVersion)
+}
+
+func TestChangelog_PreviousTagStrategySemver(t *testing.T) {
+	gc := initGitClientMock("v1.4.1", "v2.0.0", false)
+	gc.TagsFn = func() ([]string, error) {
+		return []string{"v1.3.0", "v1.4.0", "v2.0.0", "v1.4.1", "v2.0.1"}, nil
+	}
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		assert.Equal(t, []string{"v1.4.0..v1.4.1"}, refs)
+
+		return gitCommits("8b9c0d1 fix: backport crash fix"), nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		PreviousTagStrategy: changelog.PreviousTagStrategySemver,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n\n"+
+		"### Bug Fixes\n\n"+
+		"- 8b9c0d1 backport crash fix", result.Changelog)
+	assert.Zero(t, gc.PreviousTagFnInvoked)
+}
+
+func TestChangelog_PreviousTagStrategySemverFallback(t *testing.T) {
+	gc := initGitClientMock("v1.0.0", "e63c125b28842b17546cc92f635d7eccc8e909a7", false)
+	gc.TagsFn = func() ([]string, error) {
+		return []string{"v1.0.0"}, nil
+	}
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		assert.Equal(t, []string{"e63c125b28842b17546cc92f635d7eccc8e909a7..v1.0.0"}, refs)
+
+		return gitCommits("2b982db First commit"), nil
+	}
+
+	_, err := changelog.Changelog(changelog.Params{
+		PreviousTagStrategy: changelog.PreviousTagStrategySemver,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, gc.PreviousTagFnInvoked)
+}
+
+func TestChangelog_SkipPrereleases(t *testing.T) {
+	tests := map[string]struct {
+		Tag           string
+		ExpectedRange string
+	}{
+		"stable tag": {
+			Tag:           "v2.0.0",
+			ExpectedRange: "v1.9.0..v2.0.0",
+		},
+		"prerelease tag": {
+			Tag:           "v2.0.0-rc.2",
+			ExpectedRange: "v2.0.0-rc.1..v2.0.0-rc.2",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock(test.Tag, "", false)
+			gc.PreviousTagFn = func(tag string) (string, error) {
+				switch tag {
+				case "v2.0.0":
+					return "v2.0.0-rc.2", nil
+				case "v2.0.0-rc.2":
+					return "v2.0.0-rc.1", nil
+				case "v2.0.0-rc.1":
+					return "v1.9.0", nil
+				default:
+					return "", errors.New("no tag found")
+				}
+			}
+			gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+				assert.Equal(t, []string{test.ExpectedRange}, refs)
+
+				return gitCommits("8b9c0d1 fix: handle empty tag"), nil
+			}
+
+			_, err := changelog.Changelog(changelog.Params{SkipPrereleases: true}, gc)
+			require.NoError(t, err)
 		})
 	}
 }
@@ -121,6 +1023,7 @@ func TestChangelog_MakeSafeErr(t *testing.T) {
 }
 
 type gitClientMock struct {
+	mu                       sync.Mutex
 	LatestTagOrHashFn        func() string
 	LatestTagOrHashFnInvoked int
 	IsRepoFn                 func() bool
@@ -131,7 +1034,15 @@ type gitClientMock struct {
 	PreviousTagFnInvoked     int
 	TagExistsFn              func(tag string) bool
 	TagExistsFnInvoked       int
-	LogFn                    func(refs ...string) (string, error)
+	IsHeadFn                 func(ref string) (bool, error)
+	IsHeadFnInvoked          int
+	TagsFn                   func() ([]string, error)
+	TagsFnInvoked            int
+	RemoteURLFn              func() (string, error)
+	RemoteURLFnInvoked       int
+	RunFn                    func(args ...string) (string, error)
+	RunFnInvoked             int
+	LogFn                    func(refs []string, paths ...string) ([]git.Commit, error)
 	LogFnInvoked             int
 }
 
@@ -152,32 +1063,75 @@ func initGitClientMock(latestTag, previousTag string, tagExists bool) *gitClient
 		TagExistsFn: func(_ string) bool {
 			return tagExists
 		},
-		LogFn: func(refs ...string) (string, error) {
+		IsHeadFn: func(_ string) (bool, error) {
+			return true, nil
+		},
+		RemoteURLFn: func() (string, error) {
+			return "", errors.New("error: no such remote")
+		},
+		LogFn: func(refs []string, _ ...string) ([]git.Commit, error) {
 			switch refs[0] {
 			case "e63c125b28842b17546cc92f635d7eccc8e909a7..":
-				return "2b982db First commit\n", nil
+				return gitCommits("2b982db First commit"), nil
 			case "v0.1.0..v0.2.0":
-				return "2b982db First commit\n" +
-					"5a359bb Second commit\n" +
-					"1774db0 Merge pull request #1 from author/feature/feat-1\n", nil
+				return gitCommits(
+					"2b982db First commit",
+					"5a359bb Second commit",
+					"1774db0 Merge pull request #1 from author/feature/feat-1",
+				), nil
 			case "53db8447314a82e42e801568a085d424a739260a..e63c125b28842b17546cc92f635d7eccc8e909a7":
-				return "2b982db First commit\n" +
-					"5a359bb Second commit\n" +
-					"1774db0 Merge pull request #1 from author/feature/feat-1\n", nil
+				return gitCommits(
+					"2b982db First commit",
+					"5a359bb Second commit",
+					"1774db0 Merge pull request #1 from author/feature/feat-1",
+				), nil
 			case "v0.2.0..v0.3.0":
-				return "5a359bb Second commit\n" +
-					"c57f56f Third commit\n", nil
+				return gitCommits(
+					"5a359bb Second commit",
+					"c57f56f Third commit",
+				), nil
 			case "v0.1.0..v0.3.0":
-				return "2b982db First commit\n" +
-					"5a359bb Second commit\n" +
-					"c57f56f Third commit\n", nil
+				return gitCommits(
+					"2b982db First commit",
+					"5a359bb Second commit",
+					"c57f56f Third commit",
+				), nil
+			case "v0.3.0..v0.4.0":
+				return gitCommits(
+					"9f1c2d3 feat(api): add users endpoint",
+					"8b9c0d1 fix(cli): handle empty tag",
+					"6d7e8f9 docs: update readme",
+					"4e5f6a7 feat: support config file",
+					"2a3b4c5 perf: cache parsed templates",
+					"0a1b2c3 Merge pull request #2 from author/feature/feat-2",
+				), nil
 			default:
-				return "", errors.New("no tag found")
+				return nil, errors.New("no tag found")
 			}
 		},
 	}
 }
 
+// gitCommits builds git commits authored by John Doe from "<short hash> <subject>" lines.
+func gitCommits(lines ...string) []git.Commit {
+	commits := make([]git.Commit, 0, len(lines))
+
+	for _, line := range lines {
+		shortHash, subject, _ := strings.Cut(line, " ")
+
+		commits = append(commits, git.Commit{
+			Hash:        shortHash + strings.Repeat("0", 40-len(shortHash)),
+			ShortHash:   shortHash,
+			AuthorName:  "John Doe",
+			AuthorEmail: "john@example.com",
+			Date:        time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			Subject:     subject,
+		})
+	}
+
+	return commits
+}
+
 func (m *gitClientMock) IsRepo() bool {
 	m.IsRepoFnInvoked++
 	return m.IsRepoFn()
@@ -203,7 +1157,33 @@ func (m *gitClientMock) TagExists(tag string) bool {
 	return m.TagExistsFn(tag)
 }
 
-func (m *gitClientMock) Log(refs ...string) (string, error) {
+func (m *gitClientMock) IsHead(ref string) (bool, error) {
+	m.IsHeadFnInvoked++
+	return m.IsHeadFn(ref)
+}
+
+func (m *gitClientMock) Tags() ([]string, error) {
+	m.TagsFnInvoked++
+	return m.TagsFn()
+}
+
+func (m *gitClientMock) RemoteURL() (string, error) {
+	m.RemoteURLFnInvoked++
+	return m.RemoteURLFn()
+}
+
+func (m *gitClientMock) Run(args ...string) (string, error) {
+	m.mu.Lock()
+	m.RunFnInvoked++
+	m.mu.Unlock()
+
+	return m.RunFn(args...)
+}
+
+func (m *gitClientMock) Log(refs []string, paths ...string) ([]git.Commit, error) {
+	m.mu.Lock()
 	m.LogFnInvoked++
-	return m.LogFn(refs...)
+	m.mu.Unlock()
+
+	return m.LogFn(refs, paths...)
 }
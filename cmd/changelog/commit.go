@@ -0,0 +1,199 @@
+package changelog
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gandarez/changelog-action/pkg/git"
+)
+
+// conventionalCommitRegex matches a Conventional Commits header like `type(scope)!: subject`.
+var conventionalCommitRegex = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()]*)\))?(!)?:\s+(.+)$`)
+
+// breakingChangeRegex matches a `BREAKING CHANGE:` or `BREAKING-CHANGE:` footer.
+var breakingChangeRegex = regexp.MustCompile(`^BREAKING[ -]CHANGE:\s*(.*)$`)
+
+// footerRegex matches a git trailer like footer, e.g. `Refs: #123` or `Closes #123`.
+var footerRegex = regexp.MustCompile(`^([\w-]+: |[\w-]+ #)`)
+
+// Commit is a single commit parsed according to the Conventional Commits specification.
+// Commits not following the convention have an empty type and the whole header as subject.
+// BreakingChange holds the migration notes of a `BREAKING CHANGE:` footer.
+type Commit struct {
+	Hash           string    `json:"hash"`
+	ShortHash      string    `json:"short_hash"`
+	Author         string    `json:"author"`
+	AuthorEmail    string    `json:"author_email"`
+	Date           time.Time `json:"date"`
+	Header         string    `json:"header"`
+	Type           string    `json:"type"`
+	Scope          string    `json:"scope"`
+	Subject        string    `json:"subject"`
+	Body           string    `json:"body"`
+	Breaking       bool      `json:"breaking"`
+	BreakingChange string    `json:"breaking_change"`
+	Parents        []string  `json:"parents"`
+	URL            string    `json:"url,omitempty"`
+	Issues         []Issue   `json:"issues,omitempty"`
+
+	// subjectIssues are the issue references in the subject, linked when rendering markdown.
+	subjectIssues []issueRef
+}
+
+// Group is a set of commits rendered under the same changelog section.
+type Group struct {
+	Title   string   `json:"title"`
+	Commits []Commit `json:"commits"`
+}
+
+// section maps commit types to a changelog section. A section without types collects
+// every commit not matched by any other section.
+type section struct {
+	title string
+	types []string
+}
+
+func defaultSections() []section {
+	return []section{
+		{title: "Features", types: []string{"feat"}},
+		{title: "Bug Fixes", types: []string{"fix"}},
+		{title: "Performance", types: []string{"perf"}},
+		{title: "Others"},
+	}
+}
+
+// parseCommit parses a git commit according to the Conventional Commits specification.
+func parseCommit(c git.Commit) Commit {
+	commit := Commit{
+		Hash:        c.Hash,
+		ShortHash:   c.ShortHash,
+		Author:      c.AuthorName,
+		AuthorEmail: c.AuthorEmail,
+		Date:        c.Date,
+		Header:      c.Subject,
+		Subject:     c.Subject,
+		Body:        c.Body,
+		Parents:     c.Parents,
+	}
+
+	if notes, ok := parseBreakingChange(c.Body); ok {
+		commit.Breaking = true
+		commit.BreakingChange = notes
+	}
+
+	match := conventionalCommitRegex.FindStringSubmatch(commit.Subject)
+	if match == nil {
+		return commit
+	}
+
+	commit.Type = strings.ToLower(match[1])
+	commit.Scope = strings.TrimSpace(match[2])
+	commit.Breaking = commit.Breaking || match[3] == "!"
+	commit.Subject = match[4]
+
+	return commit
+}
+
+// parseBreakingChange returns the text of a breaking change footer found in body. The text spans
+// until the next footer or the end of the body.
+func parseBreakingChange(body string) (string, bool) {
+	var (
+		lines []string
+		found bool
+	)
+
+	for _, line := range strings.Split(body, "\n") {
+		if match := breakingChangeRegex.FindStringSubmatch(line); match != nil && !found {
+			found = true
+			lines = append(lines, match[1])
+
+			continue
+		}
+
+		if !found {
+			continue
+		}
+
+		if footerRegex.MatchString(line) {
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n")), found
+}
+
+// breakingChanges returns the commits introducing breaking changes.
+func breakingChanges(commits []Commit) []Commit {
+	var result []Commit
+
+	for _, commit := range commits {
+		if commit.Breaking {
+			result = append(result, commit)
+		}
+	}
+
+	return result
+}
+
+// parseCommits parses git commits into commits.
+func parseCommits(gitCommits []git.Commit) []Commit {
+	commits := make([]Commit, 0, len(gitCommits))
+
+	for _, c := range gitCommits {
+		commits = append(commits, parseCommit(c))
+	}
+
+	return commits
+}
+
+// groupCommits groups commits by section keeping the section order. Empty groups are omitted.
+func groupCommits(sections []section, commits []Commit) []Group {
+	groups := make([]Group, len(sections))
+	fallback := -1
+
+	for i, s := range sections {
+		groups[i].Title = s.title
+
+		if len(s.types) == 0 && fallback == -1 {
+			fallback = i
+		}
+	}
+
+	for _, commit := range commits {
+		idx := sectionIndex(sections, commit.Type)
+		if idx == -1 {
+			idx = fallback
+		}
+
+		if idx == -1 {
+			continue
+		}
+
+		groups[idx].Commits = append(groups[idx].Commits, commit)
+	}
+
+	var result []Group
+
+	for _, g := range groups {
+		if len(g.Commits) > 0 {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
+
+func sectionIndex(sections []section, commitType string) int {
+	for i, s := range sections {
+		for _, t := range s.types {
+			if t == commitType {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,184 @@
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SourceDefault is the source of a param not set anywhere.
+	SourceDefault = "default"
+	// SourceFlag is the source of a param set by a command line flag.
+	SourceFlag = "flag"
+	// SourceInput is the source of a param set by an action input.
+	SourceInput = "input"
+	// SourceConfig is the source of a param set by the configuration file.
+	SourceConfig = "config"
+	// SourceEvent is the source of a param read from the event payload.
+	SourceEvent = "event"
+)
+
+// GroupConfig is a changelog section declared in the configuration file. A group without
+// types collects every commit not matched by any other group.
+type GroupConfig struct {
+	Title string   `yaml:"title"`
+	Types []string `yaml:"types"`
+}
+
+// config is a repository configuration file.
+type config struct {
+	// file is the path of the file relative to the repository, empty if there is none.
+	file   string
+	groups []GroupConfig
+	// values are the inputs set by the file, lists joined by new lines as multiline inputs.
+	values map[string]string
+}
+
+// configFile is the content of a configuration file. Any key other than groups is an input.
+type configFile struct {
+	Groups []GroupConfig  `yaml:"groups"`
+	Inputs map[string]any `yaml:",inline"`
+}
+
+// defaultConfigFiles returns the configuration files looked up in the repository, in order,
+// when the config file is not set.
+func defaultConfigFiles() []string {
+	return []string{".github/changelog.yml", ".github/changelog.yaml", ".changelog.yml", ".changelog.yaml"}
+}
+
+// configInputs returns the inputs that can be set by the configuration file.
+func configInputs() []string {
+	return []string{
+		"include", "exclude", "include_authors", "exclude_authors", "collapse_authors",
+		"group_dependencies", "go_mod_diff", "go_mod_files", "include_body", "exclude_body",
+		"paths", "exclude_paths", "tag_prefix", "tag_pattern", "previous_tag_strategy",
+		"skip_prereleases", "repo_url", "repo_provider", "issue_patterns", "format", "template",
+		"calculate_next_version", "changelog_file", "changelog_header", "summary",
+		"summary_statistics", "lint_types", "lint_scopes", "lint_max_subject_length",
+	}
+}
+
+// loadConfig loads the configuration file relative to repoDir. If file is empty the default
+// files are looked up and none is required.
+func loadConfig(repoDir, file string) (config, error) {
+	candidates := []string{file}
+
+	if file == "" {
+		candidates = defaultConfigFiles()
+	}
+
+	for _, candidate := range candidates {
+		fp := candidate
+		if !filepath.IsAbs(fp) {
+			fp = filepath.Join(repoDir, fp)
+		}
+
+		data, err := os.ReadFile(fp) // nolint:gosec
+		if errors.Is(err, os.ErrNotExist) && file == "" {
+			continue
+		}
+
+		if err != nil {
+			return config{}, fmt.Errorf("failed to read config file: %s", err)
+		}
+
+		cfg, err := parseConfig(data)
+		if err != nil {
+			return config{}, fmt.Errorf("invalid config file %s: %s", candidate, err)
+		}
+
+		cfg.file = candidate
+
+		return cfg, nil
+	}
+
+	return config{}, nil
+}
+
+// parseConfig parses a configuration file. Values are scalars or lists of scalars.
+func parseConfig(data []byte) (config, error) {
+	var file configFile
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return config{}, err
+	}
+
+	var errs []error
+
+	for i, group := range file.Groups {
+		if strings.TrimSpace(group.Title) == "" {
+			errs = append(errs, fmt.Errorf("group %d has no title", i+1))
+		}
+	}
+
+	values := map[string]string{}
+
+	for _, key := range sortedKeys(file.Inputs) {
+		if !slices.Contains(configInputs(), key) {
+			errs = append(errs, fmt.Errorf("unknown key %s", key))
+
+			continue
+		}
+
+		value, ok := configValue(file.Inputs[key])
+		if !ok {
+			errs = append(errs, fmt.Errorf("invalid %s value, expected a value or a list of values", key))
+
+			continue
+		}
+
+		if value != "" {
+			values[key] = value
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return config{}, err
+	}
+
+	return config{groups: file.Groups, values: values}, nil
+}
+
+// configValue returns the value as an input. A list is joined by new lines.
+func configValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case string, bool, int, float64:
+		return fmt.Sprint(v), true
+	case []any:
+		items := make([]string, 0, len(v))
+
+		for _, item := range v {
+			s, ok := configValue(item)
+			if !ok || strings.Contains(s, "\n") {
+				return "", false
+			}
+
+			items = append(items, s)
+		}
+
+		return strings.Join(items, "\n"), true
+	default:
+		return "", false
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,78 @@
+package changelog
+
+import (
+	"regexp"
+)
+
+// bumpRegex matches a Dependabot or Renovate like dependency update subject, e.g.
+// `bump golang.org/x/net from 0.1.0 to 0.2.0` or `update module github.com/apex/log from v1.9.0 to v1.9.1`.
+var bumpRegex = regexp.MustCompile(
+	`(?i)^(?:bump|update)\s+(?:(?:module|dependency|package)\s+)?(\S+)\s+from\s+(\S+)\s+to\s+(\S+?)\.?(?:\s+in\s+(\S+))?$`)
+
+// Dependency is a dependency updated in a release, from the version before the first update
+// to the version of the last one.
+type Dependency struct {
+	Name    string   `json:"name"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Commits []string `json:"commits"`
+}
+
+// parseBump returns the dependency updated by the commit, if any. The directory of a
+// Dependabot update, e.g. `in /tools`, is kept with the name.
+func parseBump(commit Commit) (Dependency, bool) {
+	match := bumpRegex.FindStringSubmatch(commit.Subject)
+	if match == nil {
+		return Dependency{}, false
+	}
+
+	name := match[1]
+
+	if dir := match[4]; dir != "" && dir != "/" {
+		name += " (" + dir + ")"
+	}
+
+	return Dependency{
+		Name:    name,
+		From:    match[2],
+		To:      match[3],
+		Commits: []string{commit.ShortHash},
+	}, true
+}
+
+// groupDependencies splits the dependency update commits from the others. Commits are given
+// from newest to oldest, as listed by git log, and several updates of the same dependency are
+// merged from the first version to the last one. Dependencies are in order of first update.
+func groupDependencies(commits []Commit) ([]Commit, []Dependency) {
+	var (
+		rest         []Commit
+		dependencies []Dependency
+		index        = map[string]int{}
+	)
+
+	for i := len(commits) - 1; i >= 0; i-- {
+		bump, ok := parseBump(commits[i])
+		if !ok {
+			continue
+		}
+
+		if j, ok := index[bump.Name]; ok {
+			dependencies[j].To = bump.To
+			dependencies[j].Commits = append(dependencies[j].Commits, bump.Commits...)
+
+			continue
+		}
+
+		index[bump.Name] = len(dependencies)
+
+		dependencies = append(dependencies, bump)
+	}
+
+	for _, commit := range commits {
+		if _, ok := parseBump(commit); !ok {
+			rest = append(rest, commit)
+		}
+	}
+
+	return rest, dependencies
+}
@@ -0,0 +1,64 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBump(t *testing.T) {
+	tests := map[string]struct {
+		Subject  string
+		Expected Dependency
+		Ok       bool
+	}{
+		"dependabot": {
+			Subject:  "bump golang.org/x/net from 0.1.0 to 0.2.0",
+			Expected: Dependency{Name: "golang.org/x/net", From: "0.1.0", To: "0.2.0", Commits: []string{"2b982db"}},
+			Ok:       true,
+		},
+		"dependabot directory": {
+			Subject:  "Bump actions/checkout from 3 to 4 in /tools",
+			Expected: Dependency{Name: "actions/checkout (/tools)", From: "3", To: "4", Commits: []string{"2b982db"}},
+			Ok:       true,
+		},
+		"renovate": {
+			Subject:  "update module github.com/apex/log from v1.9.0 to v1.9.1.",
+			Expected: Dependency{Name: "github.com/apex/log", From: "v1.9.0", To: "v1.9.1", Commits: []string{"2b982db"}},
+			Ok:       true,
+		},
+		"no from": {
+			Subject: "update module github.com/apex/log to v1.9.1",
+		},
+		"not a bump": {
+			Subject: "bump version",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dep, ok := parseBump(Commit{ShortHash: "2b982db", Subject: test.Subject})
+
+			assert.Equal(t, test.Ok, ok)
+			assert.Equal(t, test.Expected, dep)
+		})
+	}
+}
+
+func TestGroupDependencies(t *testing.T) {
+	// newest first, as listed by git log
+	commits := []Commit{
+		{ShortHash: "9f1c2d3", Subject: "bump golang.org/x/net from 0.2.0 to 0.3.0"},
+		{ShortHash: "8b9c0d1", Subject: "add users endpoint"},
+		{ShortHash: "6d7e8f9", Subject: "bump golang.org/x/sys from 0.1.0 to 0.2.0"},
+		{ShortHash: "4e5f6a7", Subject: "bump golang.org/x/net from 0.1.0 to 0.2.0"},
+	}
+
+	rest, deps := groupDependencies(commits)
+
+	assert.Equal(t, []Commit{commits[1]}, rest)
+	assert.Equal(t, []Dependency{
+		{Name: "golang.org/x/net", From: "0.1.0", To: "0.3.0", Commits: []string{"4e5f6a7", "9f1c2d3"}},
+		{Name: "golang.org/x/sys", From: "0.1.0", To: "0.2.0", Commits: []string{"6d7e8f9"}},
+	}, deps)
+}
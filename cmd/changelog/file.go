@@ -0,0 +1,127 @@
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/apex/log"
+)
+
+// sectionHeadingRegex matches a release section heading in a changelog file.
+var sectionHeadingRegex = regexp.MustCompile(`(?m)^#{2,6}\s`)
+
+// DefaultChangelogHeader is the header of a changelog file created by the action.
+const DefaultChangelogHeader = "# Changelog"
+
+// writeChangelogFile inserts the release sections, given from oldest to newest, at the top of the
+// changelog file, below its header, creating the file if missing. Releases already having a section
+// for their version are skipped.
+func writeChangelogFile(params Params, releases ...Release) error {
+	fp := params.ChangelogFile
+	if !filepath.IsAbs(fp) {
+		fp = filepath.Join(params.RepoDir, fp)
+	}
+
+	data, err := os.ReadFile(fp) // nolint:gosec
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	content := string(data)
+
+	r, err := newSectionRenderer(params)
+	if err != nil {
+		return err
+	}
+
+	header := params.ChangelogHeader
+	if header == "" {
+		header = DefaultChangelogHeader
+	}
+
+	var changed bool
+
+	for _, release := range releases {
+		if hasVersionSection(content, release.Version) {
+			log.Warnf("changelog file %s already has a section for %s, skipping", fp, release.Version)
+
+			continue
+		}
+
+		section, err := r.Render(release)
+		if err != nil {
+			return err
+		}
+
+		content = prependSection(content, header, section)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	// nolint:gosec
+	if err := os.WriteFile(fp, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newSectionRenderer returns the renderer of a changelog file section, which is markdown titled
+// with the version unless a template is set.
+func newSectionRenderer(params Params) (renderer, error) {
+	if params.Template != "" {
+		return newRenderer(params)
+	}
+
+	return markdownRenderer{versionHeading: true}, nil
+}
+
+// hasVersionSection returns true if content has a heading starting with version,
+// optionally wrapped in brackets as in `## [v1.2.0] - 2024-01-01`.
+func hasVersionSection(content, version string) bool {
+	re := regexp.MustCompile(fmt.Sprintf(`(?m)^#{1,6}\s+\[?%s\]?(\s|$)`, regexp.QuoteMeta(version)))
+
+	return re.MatchString(content)
+}
+
+// prependSection inserts section below header and any introduction text, right before the
+// first existing section. If content does not start with the header it's added on top.
+func prependSection(content, header, section string) string {
+	section = strings.TrimSpace(section)
+
+	rest := strings.TrimLeft(content, "\n")
+	if strings.HasPrefix(rest, header) {
+		rest = strings.TrimPrefix(rest, header)
+	}
+
+	rest = strings.TrimLeft(rest, "\n")
+
+	var intro string
+
+	if loc := sectionHeadingRegex.FindStringIndex(rest); loc != nil {
+		intro, rest = rest[:loc[0]], rest[loc[0]:]
+	} else if rest != "" {
+		intro, rest = rest, ""
+	}
+
+	elements := []string{header}
+
+	if intro = strings.TrimSpace(intro); intro != "" {
+		elements = append(elements, intro)
+	}
+
+	elements = append(elements, section)
+
+	if rest = strings.TrimSpace(rest); rest != "" {
+		elements = append(elements, rest)
+	}
+
+	return strings.Join(elements, "\n\n") + "\n"
+}
@@ -0,0 +1,116 @@
+package changelog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/changelog-action/cmd/changelog"
+	"github.com/gandarez/changelog-action/pkg/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelog_ChangelogFile(t *testing.T) {
+	tests := map[string]struct {
+		Content  *string
+		Expected string
+	}{
+		"missing file": {
+			Expected: "# Changelog\n\n" +
+				"## v0.2.0 (2024-01-02)\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 add users endpoint\n",
+		},
+		"existing file": {
+			Content: strPtr("# Changelog\n\n" +
+				"All notable changes to this project.\n\n" +
+				"## v0.1.0 (2024-01-01)\n\n" +
+				"- first release\n"),
+			Expected: "# Changelog\n\n" +
+				"All notable changes to this project.\n\n" +
+				"## v0.2.0 (2024-01-02)\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 add users endpoint\n\n" +
+				"## v0.1.0 (2024-01-01)\n\n" +
+				"- first release\n",
+		},
+		"existing file without header": {
+			Content: strPtr("## [v0.1.0] - 2024-01-01\n\n- first release\n"),
+			Expected: "# Changelog\n\n" +
+				"## v0.2.0 (2024-01-02)\n\n" +
+				"### Features\n\n" +
+				"- 9f1c2d3 add users endpoint\n\n" +
+				"## [v0.1.0] - 2024-01-01\n\n" +
+				"- first release\n",
+		},
+		"version already present": {
+			Content:  strPtr("# Changelog\n\n## [v0.2.0] - 2024-02-01\n\n- second release\n"),
+			Expected: "# Changelog\n\n## [v0.2.0] - 2024-02-01\n\n- second release\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			fp := filepath.Join(repoDir, "CHANGELOG.md")
+
+			if test.Content != nil {
+				err := os.WriteFile(fp, []byte(*test.Content), 0600)
+				require.NoError(t, err)
+			}
+
+			gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+			gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+				return gitCommits("9f1c2d3 feat: add users endpoint"), nil
+			}
+
+			result, err := changelog.Changelog(changelog.Params{
+				RepoDir:         repoDir,
+				ChangelogFile:   "CHANGELOG.md",
+				ChangelogHeader: changelog.DefaultChangelogHeader,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, "## Changelog\n\n"+
+				"### Features\n\n"+
+				"- 9f1c2d3 add users endpoint", result.Changelog)
+
+			data, err := os.ReadFile(fp)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, string(data))
+		})
+	}
+}
+
+func TestChangelog_ChangelogFilePullRequest(t *testing.T) {
+	repoDir := t.TempDir()
+
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		assert.Equal(t, []string{"6dcb09b..e5bd391"}, refs)
+
+		return gitCommits("9f1c2d3 feat: add users endpoint"), nil
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		BaseSHA:         "6dcb09b",
+		HeadSHA:         "e5bd391",
+		RepoDir:         repoDir,
+		ChangelogFile:   "CHANGELOG.md",
+		ChangelogHeader: changelog.DefaultChangelogHeader,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n\n"+
+		"### Features\n\n"+
+		"- 9f1c2d3 add users endpoint", result.Changelog)
+
+	assert.NoFileExists(t, filepath.Join(repoDir, "CHANGELOG.md"))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,64 @@
+package changelog
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// multiFlag is a flag that can be repeated, its value is the list of values joined by new lines
+// as a multiline action input.
+type multiFlag []string
+
+func (m *multiFlag) String() string {
+	return strings.Join(*m, "\n")
+}
+
+func (m *multiFlag) Set(value string) error {
+	*m = append(*m, value)
+	return nil
+}
+
+// parseFlags parses the command line arguments and returns the value of each flag explicitly
+// set keyed by the matching input name, e.g. `--current-tag` as `current_tag`. A leading
+// argument is the command.
+func parseFlags(args []string) (map[string]string, error) {
+	fs := flag.NewFlagSet("changelog", flag.ContinueOnError)
+
+	fs.String("current-tag", "", "The current tag to be used instead of auto detecting")
+	fs.String("previous-tag", "", "The previous tag to be used instead of auto detecting")
+	fs.Var(&multiFlag{}, "include", "Only commit messages matching the regexp will be kept in the output (repeatable)")
+	fs.Var(&multiFlag{}, "exclude", "Commit messages matching the regexp will be removed from the output (repeatable)")
+	fs.String("repo-dir", "", "The repository path (default current dir)")
+	fs.String("config-file", "", "The configuration file, relative to the repository (default .github/changelog.yml)")
+	fs.String("format", "", "The output format: markdown, json or text (default markdown)")
+	fs.Bool("full-history", false, "Generates one section per tag for the whole history")
+	fs.String("changelog-file", "", "The changelog file to prepend the release section to")
+	fs.String("output", "", "The file to write the changelog to instead of stdout")
+	fs.Var(&multiFlag{}, "lint-types", "The commit types allowed by lint (repeatable)")
+	fs.Var(&multiFlag{}, "lint-scopes", "The commit scopes allowed by lint, any if not set (repeatable)")
+	fs.String("lint-max-subject-length", "", "The maximum commit subject length checked by lint, 0 to disable (default 100)")
+	fs.Bool("debug", false, "Enables debug mode")
+
+	values := map[string]string{}
+
+	// The command, e.g. `lint`, may be given as first argument.
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		values["command"] = args[0]
+		args = args[1:]
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument: %s", fs.Arg(0))
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		values[strings.ReplaceAll(f.Name, "-", "_")] = f.Value.String()
+	})
+
+	return values, nil
+}
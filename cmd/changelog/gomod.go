@@ -0,0 +1,300 @@
+package changelog
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/gandarez/changelog-action/pkg/semver"
+)
+
+// VersionChange is a change of a go.mod directive, e.g. `go 1.22` to `go 1.23`.
+type VersionChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ModuleChange is a change of a go.mod requirement. From is empty for added modules and To
+// for removed ones.
+type ModuleChange struct {
+	Path     string `json:"path"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Indirect bool   `json:"indirect"`
+}
+
+// GoModDiff is the difference of a go.mod file between two refs.
+type GoModDiff struct {
+	File       string         `json:"file"`
+	Go         *VersionChange `json:"go,omitempty"`
+	Toolchain  *VersionChange `json:"toolchain,omitempty"`
+	Added      []ModuleChange `json:"added,omitempty"`
+	Removed    []ModuleChange `json:"removed,omitempty"`
+	Upgraded   []ModuleChange `json:"upgraded,omitempty"`
+	Downgraded []ModuleChange `json:"downgraded,omitempty"`
+}
+
+// goMod holds the directives of a go.mod file relevant to the diff.
+type goMod struct {
+	goVersion string
+	toolchain string
+	// requires maps the required modules to their version.
+	requires map[string]string
+	indirect map[string]bool
+}
+
+// goModDiffs returns the changes of the go.mod files between previousTag and tag. Files are
+// the given ones or, if empty, every go.mod in tag within paths. Unchanged files are omitted.
+func goModDiffs(gc gitClient, files, paths []string, previousTag, tag string) ([]GoModDiff, error) {
+	if len(files) == 0 {
+		var err error
+
+		files, err = goModFiles(gc, paths, tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var diffs []GoModDiff
+
+	for _, file := range files {
+		before := readGoMod(gc, previousTag, file)
+		after := readGoMod(gc, tag, file)
+
+		diff := diffGoMod(file, before, after)
+
+		if !diff.empty() {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs, nil
+}
+
+// goModFiles lists the go.mod files of ref within paths.
+func goModFiles(gc gitClient, paths []string, ref string) ([]string, error) {
+	out, err := gc.Run("ls-tree", "-r", "--name-only", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %s", ref, err)
+	}
+
+	var files []string
+
+	for _, file := range strings.Split(out, "\n") {
+		file = strings.TrimSpace(file)
+
+		if path.Base(file) != "go.mod" || !withinPaths(file, paths) {
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func withinPaths(file string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+
+	for _, p := range paths {
+		p = strings.Trim(p, "/")
+
+		if p == "" || p == "." || file == p || strings.HasPrefix(file, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readGoMod reads the go.mod file at ref. A missing file is read as empty.
+func readGoMod(gc gitClient, ref, file string) goMod {
+	out, err := gc.Run("show", ref+":"+file)
+	if err != nil {
+		log.Debugf("failed to read %s at %s: %s", file, ref, err)
+
+		out = ""
+	}
+
+	return parseGoMod(out)
+}
+
+// parseGoMod parses the go, toolchain and require directives of a go.mod file.
+func parseGoMod(content string) goMod {
+	mod := goMod{requires: map[string]string{}, indirect: map[string]bool{}}
+
+	var inRequire bool
+
+	for _, line := range strings.Split(content, "\n") {
+		directive, comment, _ := strings.Cut(line, "//")
+		fields := strings.Fields(directive)
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inRequire && fields[0] == ")":
+			inRequire = false
+
+			continue
+		case inRequire:
+		case fields[0] == "go" && len(fields) == 2:
+			mod.goVersion = fields[1]
+
+			continue
+		case fields[0] == "toolchain" && len(fields) == 2:
+			mod.toolchain = fields[1]
+
+			continue
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequire = true
+
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) != 2 {
+			continue
+		}
+
+		module := strings.Trim(fields[0], `"`)
+
+		mod.requires[module] = fields[1]
+		mod.indirect[module] = strings.TrimSpace(comment) == "indirect"
+	}
+
+	return mod
+}
+
+// diffGoMod compares two versions of a go.mod file. Modules are sorted by path.
+func diffGoMod(file string, before, after goMod) GoModDiff {
+	diff := GoModDiff{File: file}
+
+	if before.goVersion != after.goVersion {
+		diff.Go = &VersionChange{From: before.goVersion, To: after.goVersion}
+	}
+
+	if before.toolchain != after.toolchain {
+		diff.Toolchain = &VersionChange{From: before.toolchain, To: after.toolchain}
+	}
+
+	for _, module := range sortedModules(after.requires) {
+		to := after.requires[module]
+		from, ok := before.requires[module]
+
+		change := ModuleChange{Path: module, From: from, To: to, Indirect: after.indirect[module]}
+
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, change)
+		case from == to:
+		case compareModuleVersions(from, to) > 0:
+			diff.Downgraded = append(diff.Downgraded, change)
+		default:
+			diff.Upgraded = append(diff.Upgraded, change)
+		}
+	}
+
+	for _, module := range sortedModules(before.requires) {
+		if _, ok := after.requires[module]; !ok {
+			diff.Removed = append(diff.Removed, ModuleChange{
+				Path:     module,
+				From:     before.requires[module],
+				Indirect: before.indirect[module],
+			})
+		}
+	}
+
+	return diff
+}
+
+func sortedModules(requires map[string]string) []string {
+	modules := make([]string, 0, len(requires))
+
+	for module := range requires {
+		modules = append(modules, module)
+	}
+
+	sort.Strings(modules)
+
+	return modules
+}
+
+// compareModuleVersions compares module versions by semantic version precedence, falling back
+// to string comparison for invalid versions.
+func compareModuleVersions(a, b string) int {
+	va, errA := semver.Parse(strings.TrimSuffix(a, "+incompatible"))
+	vb, errB := semver.Parse(strings.TrimSuffix(b, "+incompatible"))
+
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	return semver.Compare(va, vb)
+}
+
+func (d GoModDiff) empty() bool {
+	return d.Go == nil && d.Toolchain == nil &&
+		len(d.Added)+len(d.Removed)+len(d.Upgraded)+len(d.Downgraded) == 0
+}
+
+// showGoModFile returns true if the go.mod file is titled when rendering, i.e. unless it's a
+// single go.mod at the repository root.
+func showGoModFile(diffs []GoModDiff) bool {
+	return len(diffs) > 1 || (len(diffs) == 1 && diffs[0].File != "go.mod")
+}
+
+// lines returns a line per change of the go.mod file.
+func (d GoModDiff) lines() []string {
+	var lines []string
+
+	if d.Go != nil {
+		lines = append(lines, fmt.Sprintf("go: %s → %s", orNone(d.Go.From), orNone(d.Go.To)))
+	}
+
+	if d.Toolchain != nil {
+		lines = append(lines, fmt.Sprintf("toolchain: %s → %s", orNone(d.Toolchain.From), orNone(d.Toolchain.To)))
+	}
+
+	for _, m := range d.Added {
+		lines = append(lines, fmt.Sprintf("Added %s %s%s", m.Path, m.To, indirectSuffix(m)))
+	}
+
+	for _, m := range d.Removed {
+		lines = append(lines, fmt.Sprintf("Removed %s %s%s", m.Path, m.From, indirectSuffix(m)))
+	}
+
+	for _, m := range d.Upgraded {
+		lines = append(lines, fmt.Sprintf("Upgraded %s %s → %s%s", m.Path, m.From, m.To, indirectSuffix(m)))
+	}
+
+	for _, m := range d.Downgraded {
+		lines = append(lines, fmt.Sprintf("Downgraded %s %s → %s%s", m.Path, m.From, m.To, indirectSuffix(m)))
+	}
+
+	return lines
+}
+
+func orNone(version string) string {
+	if version == "" {
+		return "none"
+	}
+
+	return version
+}
+
+func indirectSuffix(m ModuleChange) string {
+	if m.Indirect {
+		return " (indirect)"
+	}
+
+	return ""
+}
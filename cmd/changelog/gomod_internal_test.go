@@ -0,0 +1,70 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffGoMod(t *testing.T) {
+	before := parseGoMod(`module github.com/owner/repo
+
+go 1.22
+
+require (
+	github.com/apex/log v1.9.0
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/net v0.20.0 // indirect
+	golang.org/x/sys v0.0.0-20240101000000-abcdefabcdef // indirect
+)
+
+require github.com/old/dep v1.0.0
+`)
+
+	after := parseGoMod(`module github.com/owner/repo
+
+go 1.23
+
+toolchain go1.23.2
+
+require (
+	github.com/apex/log v1.9.0
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.1.0 // indirect
+	"github.com/new/dep" v0.1.0
+)
+`)
+
+	assert.Equal(t, GoModDiff{
+		File:      "go.mod",
+		Go:        &VersionChange{From: "1.22", To: "1.23"},
+		Toolchain: &VersionChange{To: "go1.23.2"},
+		Added: []ModuleChange{
+			{Path: "github.com/new/dep", To: "v0.1.0"},
+		},
+		Removed: []ModuleChange{
+			{Path: "github.com/old/dep", From: "v1.0.0"},
+		},
+		Upgraded: []ModuleChange{
+			{Path: "golang.org/x/net", From: "v0.20.0", To: "v0.21.0", Indirect: true},
+			{Path: "golang.org/x/sys", From: "v0.0.0-20240101000000-abcdefabcdef", To: "v0.1.0", Indirect: true},
+		},
+		Downgraded: []ModuleChange{
+			{Path: "github.com/stretchr/testify", From: "v1.9.0", To: "v1.8.4"},
+		},
+	}, diffGoMod("go.mod", before, after))
+}
+
+func TestDiffGoMod_Unchanged(t *testing.T) {
+	mod := parseGoMod("module github.com/owner/repo\n\ngo 1.23\n\nrequire github.com/apex/log v1.9.0\n")
+
+	assert.True(t, diffGoMod("go.mod", mod, mod).empty())
+}
+
+func TestWithinPaths(t *testing.T) {
+	assert.True(t, withinPaths("go.mod", nil))
+	assert.True(t, withinPaths("services/api/go.mod", []string{"services/api/"}))
+	assert.False(t, withinPaths("services/apiv2/go.mod", []string{"services/api"}))
+	assert.False(t, withinPaths("go.mod", []string{"services/api"}))
+}
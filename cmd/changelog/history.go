@@ -0,0 +1,109 @@
+package changelog
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/gandarez/changelog-action/pkg/git"
+	"github.com/gandarez/changelog-action/pkg/semver"
+)
+
+// historyRange is the range of a single release in the full history.
+type historyRange struct {
+	previousTag string
+	tag         string
+}
+
+// fullHistory collects one release per tag, from oldest to newest. Releases are collected concurrently.
+func fullHistory(params Params, gc gitClient, remote *git.Remote) ([]Release, error) {
+	tags, err := gc.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %s", err)
+	}
+
+	if params.PreviousTagStrategy == PreviousTagStrategySemver {
+		tags = sortTagsBySemver(params.versionPrefix(), tags)
+	}
+
+	ranges := historyRanges(params, tags)
+	releases := make([]Release, len(ranges))
+	errs := make([]error, len(ranges))
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, runtime.GOMAXPROCS(0))
+	)
+
+	for i, r := range ranges {
+		wg.Add(1)
+
+		go func(i int, r historyRange) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			releases[i], errs[i] = collectRelease(params, gc, remote, r.previousTag, r.tag)
+		}(i, r)
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect release %s: %s", ranges[i].tag, err)
+		}
+	}
+
+	return releases, nil
+}
+
+// historyRanges returns the range of each tag. The first tag includes every reachable commit. When
+// skipping prereleases, stable tags start from the previous stable tag.
+func historyRanges(params Params, tags []string) []historyRange {
+	prefix := params.versionPrefix()
+	ranges := make([]historyRange, 0, len(tags))
+
+	var previous, previousStable string
+
+	for _, tag := range tags {
+		r := historyRange{previousTag: previous, tag: tag}
+
+		if params.SkipPrereleases && isStableTag(prefix, tag) {
+			r.previousTag = previousStable
+		}
+
+		ranges = append(ranges, r)
+
+		previous = tag
+
+		if !isPrereleaseTag(prefix, tag) {
+			previousStable = tag
+		}
+	}
+
+	return ranges
+}
+
+// sortTagsBySemver sorts tags from lowest to highest version. Tags not being a semantic version
+// are kept in their original order before versioned ones.
+func sortTagsBySemver(prefix string, tags []string) []string {
+	sorted := make([]string, len(tags))
+	copy(sorted, tags)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, aErr := parseTagVersion(prefix, sorted[i])
+		b, bErr := parseTagVersion(prefix, sorted[j])
+
+		switch {
+		case aErr != nil || bErr != nil:
+			return aErr != nil && bErr == nil
+		default:
+			return semver.Compare(a, b) < 0
+		}
+	})
+
+	return sorted
+}
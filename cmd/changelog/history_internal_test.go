@@ -0,0 +1,51 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryRanges(t *testing.T) {
+	tags := []string{"v1.0.0", "v2.0.0-rc.1", "v2.0.0-rc.2", "v2.0.0"}
+
+	tests := map[string]struct {
+		SkipPrereleases bool
+		Expected        []historyRange
+	}{
+		"all tags": {
+			Expected: []historyRange{
+				{tag: "v1.0.0"},
+				{previousTag: "v1.0.0", tag: "v2.0.0-rc.1"},
+				{previousTag: "v2.0.0-rc.1", tag: "v2.0.0-rc.2"},
+				{previousTag: "v2.0.0-rc.2", tag: "v2.0.0"},
+			},
+		},
+		"skip prereleases": {
+			SkipPrereleases: true,
+			Expected: []historyRange{
+				{tag: "v1.0.0"},
+				{previousTag: "v1.0.0", tag: "v2.0.0-rc.1"},
+				{previousTag: "v2.0.0-rc.1", tag: "v2.0.0-rc.2"},
+				{previousTag: "v1.0.0", tag: "v2.0.0"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ranges := historyRanges(Params{SkipPrereleases: test.SkipPrereleases}, tags)
+
+			assert.Equal(t, test.Expected, ranges)
+		})
+	}
+}
+
+func TestSortTagsBySemver(t *testing.T) {
+	tags := []string{"v1.1.0", "latest", "v1.0.1", "v2.0.0-rc.1", "v1.10.0", "v2.0.0"}
+
+	assert.Equal(t,
+		[]string{"latest", "v1.0.1", "v1.1.0", "v1.10.0", "v2.0.0-rc.1", "v2.0.0"},
+		sortTagsBySemver("", tags),
+	)
+}
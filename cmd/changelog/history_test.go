@@ -0,0 +1,262 @@
+package changelog_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/changelog-action/cmd/changelog"
+	"github.com/gandarez/changelog-action/pkg/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelog_FullHistory(t *testing.T) {
+	gc := initGitClientMock("", "", false)
+	gc.TagsFn = func() ([]string, error) {
+		return []string{"v0.1.0", "v0.2.0", "v0.3.0"}, nil
+	}
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		switch refs[0] {
+		case "v0.1.0":
+			return gitCommits("2b982db feat: first commit"), nil
+		case "v0.1.0..v0.2.0":
+			return gitCommits("5a359bb fix: second commit"), nil
+		case "v0.2.0..v0.3.0":
+			return gitCommits("c57f56f docs: third commit"), nil
+		default:
+			return nil, errors.New("unexpected range")
+		}
+	}
+
+	tests := map[string]struct {
+		Format   string
+		Expected string
+	}{
+		"markdown": {
+			Format: changelog.FormatMarkdown,
+			Expected: "## v0.3.0 \\(2024-01-02\\)\n\n" +
+				"### Others\n\n" +
+				"- c57f56f third commit\n\n" +
+				"## v0.2.0 \\(2024-01-02\\)\n\n" +
+				"### Bug Fixes\n\n" +
+				"- 5a359bb second commit\n\n" +
+				"## v0.1.0 \\(2024-01-02\\)\n\n" +
+				"### Features\n\n" +
+				"- 2b982db first commit",
+		},
+		"text": {
+			Format: changelog.FormatText,
+			Expected: "v0.3.0\n\nOthers\n  c57f56f third commit\n\n" +
+				"v0.2.0\n\nBug Fixes\n  5a359bb second commit\n\n" +
+				"v0.1.0\n\nFeatures\n  2b982db first commit",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := changelog.Changelog(changelog.Params{
+				Format:      test.Format,
+				FullHistory: true,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Regexp(t, "^"+test.Expected+"$", result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_FullHistoryNextVersion(t *testing.T) {
+	gc := initGitClientMock("", "", false)
+	gc.TagsFn = func() ([]string, error) {
+		return []string{"v0.1.0", "v0.2.0"}, nil
+	}
+	gc.IsHeadFn = func(ref string) (bool, error) {
+		assert.Equal(t, "v0.2.0", ref)
+
+		return false, nil
+	}
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		switch refs[0] {
+		case "v0.1.0":
+			return gitCommits("2b982db feat: first commit"), nil
+		case "v0.1.0..v0.2.0":
+			return gitCommits("5a359bb fix: second commit"), nil
+		case "v0.2.0..HEAD":
+			return gitCommits("c57f56f feat: unreleased commit"), nil
+		default:
+			return nil, errors.New("unexpected range")
+		}
+	}
+
+	result, err := changelog.Changelog(changelog.Params{FullHistory: true, NextVersion: true}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.3.0", result.NextVersion)
+	assert.Equal(t, 1, gc.IsHeadFnInvoked)
+}
+
+func TestChangelog_FullHistoryPullRequest(t *testing.T) {
+	repoDir := t.TempDir()
+
+	gc := initGitClientMock("", "", false)
+	gc.TagsFn = func() ([]string, error) {
+		return []string{"v0.1.0", "v0.2.0"}, nil
+	}
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		switch refs[0] {
+		case "v0.1.0":
+			return gitCommits("2b982db feat: first commit"), nil
+		case "v0.1.0..v0.2.0":
+			return gitCommits("5a359bb fix: second commit"), nil
+		default:
+			return nil, errors.New("unexpected range")
+		}
+	}
+
+	result, err := changelog.Changelog(changelog.Params{
+		BaseSHA:         "6dcb09b",
+		HeadSHA:         "e5bd391",
+		RepoDir:         repoDir,
+		FullHistory:     true,
+		NextVersion:     true,
+		ChangelogFile:   "CHANGELOG.md",
+		ChangelogHeader: changelog.DefaultChangelogHeader,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Contains(t, result.Changelog, "## v0.2.0")
+	assert.Contains(t, result.Changelog, "## v0.1.0")
+	assert.Empty(t, result.NextVersion)
+	assert.NoFileExists(t, filepath.Join(repoDir, "CHANGELOG.md"))
+}
+
+func TestChangelog_FullHistoryJSON(t *testing.T) {
+	tests := map[string]struct {
+		Tags     []string
+		Expected string
+	}{
+		"several tags": {
+			Tags: []string{"v0.1.0", "v0.2.0"},
+			Expected: `[
+			{
+				"version": "v0.2.0",
+				"previous_version": "v0.1.0",
+				"range": "v0.1.0..v0.2.0",
+				"date": "2024-01-02T15:04:05Z",
+				"has_breaking_changes": false,
+				"commits": [{
+					"hash": "5a359bb000000000000000000000000000000000",
+					"short_hash": "5a359bb",
+					"author": "John Doe",
+					"author_email": "john@example.com",
+					"date": "2024-01-02T15:04:05Z",
+					"header": "Second commit",
+					"type": "",
+					"scope": "",
+					"subject": "Second commit",
+					"body": "",
+					"breaking": false,
+					"breaking_change": "",
+					"parents": null
+				}]
+			},
+			{
+				"version": "v0.1.0",
+				"previous_version": "",
+				"range": "v0.1.0",
+				"date": "2024-01-02T15:04:05Z",
+				"has_breaking_changes": false,
+				"commits": [{
+					"hash": "2b982db000000000000000000000000000000000",
+					"short_hash": "2b982db",
+					"author": "John Doe",
+					"author_email": "john@example.com",
+					"date": "2024-01-02T15:04:05Z",
+					"header": "First commit",
+					"type": "",
+					"scope": "",
+					"subject": "First commit",
+					"body": "",
+					"breaking": false,
+					"breaking_change": "",
+					"parents": null
+				}]
+			}
+		]`,
+		},
+		"one tag": {
+			Tags: []string{"v0.1.0"},
+			Expected: `[
+			{
+				"version": "v0.1.0",
+				"previous_version": "",
+				"range": "v0.1.0",
+				"date": "2024-01-02T15:04:05Z",
+				"has_breaking_changes": false,
+				"commits": [{
+					"hash": "2b982db000000000000000000000000000000000",
+					"short_hash": "2b982db",
+					"author": "John Doe",
+					"author_email": "john@example.com",
+					"date": "2024-01-02T15:04:05Z",
+					"header": "First commit",
+					"type": "",
+					"scope": "",
+					"subject": "First commit",
+					"body": "",
+					"breaking": false,
+					"breaking_change": "",
+					"parents": null
+				}]
+			}
+		]`,
+		},
+		"no tags": {
+			Expected: "[]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := initGitClientMock("", "", false)
+			gc.TagsFn = func() ([]string, error) {
+				return test.Tags, nil
+			}
+			gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+				if refs[0] == "v0.1.0" {
+					return gitCommits("2b982db First commit"), nil
+				}
+
+				return gitCommits("5a359bb Second commit"), nil
+			}
+
+			result, err := changelog.Changelog(changelog.Params{
+				Format:      changelog.FormatJSON,
+				FullHistory: true,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.Expected, result.Changelog)
+		})
+	}
+}
+
+func TestChangelog_FullHistoryErr(t *testing.T) {
+	gc := initGitClientMock("", "", false)
+	gc.TagsFn = func() ([]string, error) {
+		return []string{"v0.1.0", "v0.2.0"}, nil
+	}
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		if refs[0] == "v0.1.0..v0.2.0" {
+			return nil, errors.New("bad revision")
+		}
+
+		return nil, nil
+	}
+
+	_, err := changelog.Changelog(changelog.Params{FullHistory: true}, gc)
+
+	assert.EqualError(t, err, "failed to collect release v0.2.0: failed to get log: bad revision")
+}
@@ -0,0 +1,199 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gandarez/changelog-action/pkg/git"
+)
+
+// Issue is an issue or pull request referenced by a commit.
+type Issue struct {
+	Reference string `json:"reference"`
+	URL       string `json:"url"`
+}
+
+// issuePattern links the references matched by regex to url. The url is expanded with the
+// submatches like `$1` or `${number}`. The reference is the `ref` named group if present,
+// otherwise the whole match.
+type issuePattern struct {
+	regex *regexp.Regexp
+	url   string
+}
+
+// issueRef is an issue reference found in a text, from start to end.
+type issueRef struct {
+	Issue
+	start int
+	end   int
+}
+
+// parseIssuePatterns parses issue patterns given as `<regexp> => <url>` lines.
+func parseIssuePatterns(lines []string) ([]issuePattern, error) {
+	patterns := make([]issuePattern, 0, len(lines))
+
+	for _, line := range lines {
+		expr, url, ok := strings.Cut(line, "=>")
+		if !ok || strings.TrimSpace(expr) == "" || strings.TrimSpace(url) == "" {
+			return nil, fmt.Errorf("invalid issue pattern, expected `<regexp> => <url>`: %s", line)
+		}
+
+		r, err := regexp.Compile(strings.TrimSpace(expr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue pattern %q: %s", line, err)
+		}
+
+		patterns = append(patterns, issuePattern{regex: r, url: strings.TrimSpace(url)})
+	}
+
+	return patterns, nil
+}
+
+// defaultIssuePatterns returns the patterns linking `owner/repo#N` and `#N` references to the
+// issues of the remote host.
+func defaultIssuePatterns(remote git.Remote) []issuePattern {
+	return []issuePattern{
+		{
+			regex: regexp.MustCompile(`(?:^|[^\w./-])(?P<ref>(?P<repo>[\w.-]+/[\w.-]+)#(?P<number>\d+))\b`),
+			url:   remote.WithRepo("${repo}").IssueURL("${number}"),
+		},
+		{
+			regex: regexp.MustCompile(`(?:^|[^\w/])(?P<ref>#(?P<number>\d+))\b`),
+			url:   remote.IssueURL("${number}"),
+		},
+	}
+}
+
+// issuePatterns returns the user patterns followed by the default ones when remote is set.
+func issuePatterns(lines []string, remote *git.Remote) ([]issuePattern, error) {
+	patterns, err := parseIssuePatterns(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	if remote != nil {
+		patterns = append(patterns, defaultIssuePatterns(*remote)...)
+	}
+
+	return patterns, nil
+}
+
+// findIssues returns the issue references found in text ordered by position. When references
+// overlap the first starting one wins, and on ties the first pattern.
+func findIssues(patterns []issuePattern, text string) []issueRef {
+	var found []issueRef
+
+	for _, p := range patterns {
+		group := p.regex.SubexpIndex("ref")
+
+		for _, match := range p.regex.FindAllStringSubmatchIndex(text, -1) {
+			start, end := match[0], match[1]
+
+			if group > 0 && match[2*group] >= 0 {
+				start, end = match[2*group], match[2*group+1]
+			}
+
+			url := p.regex.ExpandString(nil, p.url, text, match)
+
+			found = append(found, issueRef{
+				Issue: Issue{Reference: text[start:end], URL: string(url)},
+				start: start,
+				end:   end,
+			})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].start < found[j].start
+	})
+
+	var (
+		result []issueRef
+		last   = -1
+	)
+
+	for _, ref := range found {
+		if ref.start < last {
+			continue
+		}
+
+		result = append(result, ref)
+		last = ref.end
+	}
+
+	return result
+}
+
+// linkIssues sets the issues referenced by the subject and body of each commit.
+func linkIssues(patterns []issuePattern, commits []Commit) {
+	if len(patterns) == 0 {
+		return
+	}
+
+	for i := range commits {
+		commits[i].subjectIssues = findIssues(patterns, commits[i].Subject)
+
+		var issues []Issue
+
+		for _, ref := range commits[i].subjectIssues {
+			issues = append(issues, ref.Issue)
+		}
+
+		for _, ref := range findIssues(patterns, commits[i].Body) {
+			issues = append(issues, ref.Issue)
+		}
+
+		commits[i].Issues = uniqueIssues(issues)
+	}
+}
+
+// releaseIssues returns the issues referenced by commits, once each, in order of appearance.
+func releaseIssues(commits []Commit) []Issue {
+	var issues []Issue
+
+	for _, commit := range commits {
+		issues = append(issues, commit.Issues...)
+	}
+
+	return uniqueIssues(issues)
+}
+
+func uniqueIssues(issues []Issue) []Issue {
+	var (
+		result []Issue
+		seen   = map[string]bool{}
+	)
+
+	for _, issue := range issues {
+		if seen[issue.Reference] {
+			continue
+		}
+
+		seen[issue.Reference] = true
+
+		result = append(result, issue)
+	}
+
+	return result
+}
+
+// markdownSubject returns the subject with its issue references as markdown links.
+func markdownSubject(commit Commit) string {
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, ref := range commit.subjectIssues {
+		b.WriteString(commit.Subject[last:ref.start])
+		b.WriteString("[" + ref.Reference + "](" + ref.URL + ")")
+
+		last = ref.end
+	}
+
+	b.WriteString(commit.Subject[last:])
+
+	return b.String()
+}
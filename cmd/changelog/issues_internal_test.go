@@ -0,0 +1,95 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/gandarez/changelog-action/pkg/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindIssues(t *testing.T) {
+	remote := git.Remote{Provider: git.ProviderGitHub, BaseURL: "https://github.com/owner/repo"}
+
+	jira, err := parseIssuePatterns([]string{`\b(?P<ref>[A-Z][A-Z0-9]+-\d+)\b => https://jira.example.com/browse/${ref}`})
+	require.NoError(t, err)
+
+	patterns := append(jira, defaultIssuePatterns(remote)...)
+
+	tests := map[string]struct {
+		Text     string
+		Expected []Issue
+	}{
+		"github issue": {
+			Text:     "crash on start (#123)",
+			Expected: []Issue{{Reference: "#123", URL: "https://github.com/owner/repo/issues/123"}},
+		},
+		"other repository": {
+			Text:     "bump dependency, see other/lib#7",
+			Expected: []Issue{{Reference: "other/lib#7", URL: "https://github.com/other/lib/issues/7"}},
+		},
+		"jira": {
+			Text: "Fixes JIRA-456 and #9",
+			Expected: []Issue{
+				{Reference: "JIRA-456", URL: "https://jira.example.com/browse/JIRA-456"},
+				{Reference: "#9", URL: "https://github.com/owner/repo/issues/9"},
+			},
+		},
+		"not a reference": {
+			Text: "use path/to#anchor and color #fff or a#1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var issues []Issue
+
+			for _, ref := range findIssues(patterns, test.Text) {
+				assert.Equal(t, ref.Reference, test.Text[ref.start:ref.end])
+
+				issues = append(issues, ref.Issue)
+			}
+
+			assert.Equal(t, test.Expected, issues)
+		})
+	}
+}
+
+func TestParseIssuePatternsErr(t *testing.T) {
+	tests := map[string]struct {
+		Line     string
+		Expected string
+	}{
+		"missing url": {
+			Line:     `JIRA-\d+`,
+			Expected: "invalid issue pattern, expected `<regexp> => <url>`: JIRA-\\d+",
+		},
+		"invalid regexp": {
+			Line: `JIRA-(\d+ => https://jira.example.com/browse/$0`,
+			Expected: "invalid issue pattern \"JIRA-(\\\\d+ => https://jira.example.com/browse/$0\": " +
+				"error parsing regexp: missing closing ): `JIRA-(\\d+`",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseIssuePatterns([]string{test.Line})
+
+			assert.EqualError(t, err, test.Expected)
+		})
+	}
+}
+
+func TestMarkdownSubject(t *testing.T) {
+	remote := git.Remote{Provider: git.ProviderGitHub, BaseURL: "https://github.com/owner/repo"}
+
+	commits := []Commit{{Subject: "crash on start (#123), see other/lib#7"}}
+	linkIssues(defaultIssuePatterns(remote), commits)
+
+	assert.Equal(t,
+		"crash on start ([#123](https://github.com/owner/repo/issues/123)), "+
+			"see [other/lib#7](https://github.com/other/lib/issues/7)",
+		markdownSubject(commits[0]),
+	)
+}
@@ -0,0 +1,111 @@
+package changelog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/gandarez/changelog-action/pkg/git"
+)
+
+const (
+	// CommandChangelog generates the changelog.
+	CommandChangelog = "changelog"
+	// CommandLint checks the commit messages of the range.
+	CommandLint = "lint"
+	// DefaultLintMaxSubjectLength is the default maximum length of a commit subject line.
+	DefaultLintMaxSubjectLength = 100
+)
+
+// DefaultLintTypes returns the commit types allowed by default, as in the Angular convention.
+func DefaultLintTypes() []string {
+	return []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+}
+
+// LintViolation is a commit not following the convention.
+type LintViolation struct {
+	Commit   Commit
+	Problems []string
+}
+
+// RunLint checks the commit messages of the repository in params.
+func RunLint(params Params) ([]LintViolation, error) {
+	if params.Debug {
+		log.SetLevel(log.DebugLevel)
+		log.Debug("debug logs enabled\n")
+	}
+
+	log.Debug(params.String())
+
+	git := git.NewGit(params.RepoDir)
+	git.TagPattern = params.tagGlob()
+
+	return Lint(params, git)
+}
+
+// Lint checks every commit of the range, except merge commits and the filtered ones, against
+// the Conventional Commits specification, the allowed types and scopes and the maximum subject length.
+func Lint(params Params, gc gitClient) ([]LintViolation, error) {
+	if err := checkRepo(gc); err != nil {
+		return nil, err
+	}
+
+	previousTag, tag, err := resolveRange(params, gc)
+	if err != nil {
+		return nil, err
+	}
+
+	gitCommits, err := gc.Log([]string{logRange(previousTag, tag)}, pathspecs(params.Paths, params.ExcludePaths)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %s", err)
+	}
+
+	commits, err := applyFilters(params.commitFilters(), parseCommits(gitCommits))
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []LintViolation
+
+	for _, commit := range commits {
+		if len(commit.Parents) > 1 {
+			continue
+		}
+
+		if problems := lintCommit(params, commit); len(problems) > 0 {
+			violations = append(violations, LintViolation{Commit: commit, Problems: problems})
+		}
+	}
+
+	return violations, nil
+}
+
+// lintCommit returns the problems of the commit message.
+func lintCommit(params Params, commit Commit) []string {
+	var problems []string
+
+	if commit.Type == "" {
+		problems = append(problems, "header does not follow the Conventional Commits format `type(scope): subject`")
+	}
+
+	if commit.Type != "" && len(params.LintTypes) > 0 && !slices.Contains(params.LintTypes, commit.Type) {
+		problems = append(problems, fmt.Sprintf("type %q is not allowed, expected one of: %s",
+			commit.Type, strings.Join(params.LintTypes, ", ")))
+	}
+
+	if commit.Scope != "" && len(params.LintScopes) > 0 {
+		for _, scope := range strings.Split(commit.Scope, ",") {
+			if scope = strings.TrimSpace(scope); !slices.Contains(params.LintScopes, scope) {
+				problems = append(problems, fmt.Sprintf("scope %q is not allowed, expected one of: %s",
+					scope, strings.Join(params.LintScopes, ", ")))
+			}
+		}
+	}
+
+	if limit := params.LintMaxSubjectLength; limit > 0 && len([]rune(commit.Header)) > limit {
+		problems = append(problems, fmt.Sprintf("subject is longer than %d characters", limit))
+	}
+
+	return problems
+}
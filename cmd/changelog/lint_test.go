@@ -0,0 +1,76 @@
+package changelog_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gandarez/changelog-action/cmd/changelog"
+	"github.com/gandarez/changelog-action/pkg/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	log := gitCommits(
+		"9f1c2d3 feat(api): add users endpoint",
+		"8b9c0d1 Fix crash on start",
+		"6d7e8f9 wip(cli): handle empty tag",
+		"4e5f6a7 fix(db,web): handle empty tag",
+		"2a3b4c5 docs: explain how the previous tag is detected when the current tag is not set in the inputs",
+		"0a1b2c3 Merge pull request #2 from author/feature/feat-2",
+	)
+	log[5].Parents = []string{"2a3b4c5", "1774db0"}
+
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.LogFn = func(refs []string, _ ...string) ([]git.Commit, error) {
+		assert.Equal(t, []string{"v0.1.0..v0.2.0"}, refs)
+
+		return log, nil
+	}
+
+	violations, err := changelog.Lint(changelog.Params{
+		LintTypes:            changelog.DefaultLintTypes(),
+		LintScopes:           []string{"api", "cli", "db"},
+		LintMaxSubjectLength: 72,
+	}, gc)
+	require.NoError(t, err)
+
+	var problems = map[string][]string{}
+
+	for _, v := range violations {
+		problems[v.Commit.ShortHash] = v.Problems
+	}
+
+	assert.Equal(t, map[string][]string{
+		"8b9c0d1": {"header does not follow the Conventional Commits format `type(scope): subject`"},
+		"6d7e8f9": {"type \"wip\" is not allowed, expected one of: " +
+			"build, chore, ci, docs, feat, fix, perf, refactor, revert, style, test"},
+		"4e5f6a7": {"scope \"web\" is not allowed, expected one of: api, cli, db"},
+		"2a3b4c5": {"subject is longer than 72 characters"},
+	}, problems)
+}
+
+func TestLint_Exclude(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+
+	violations, err := changelog.Lint(changelog.Params{
+		Exclude: []string{"^Merge pull request"},
+	}, gc)
+	require.NoError(t, err)
+
+	require.Len(t, violations, 2)
+	assert.Equal(t, "2b982db", violations[0].Commit.ShortHash)
+	assert.Equal(t, "5a359bb", violations[1].Commit.ShortHash)
+}
+
+func TestLint_LogErr(t *testing.T) {
+	gc := initGitClientMock("v0.2.0", "v0.1.0", false)
+	gc.LogFn = func(_ []string, _ ...string) ([]git.Commit, error) {
+		return nil, errors.New("error")
+	}
+
+	_, err := changelog.Lint(changelog.Params{}, gc)
+
+	assert.EqualError(t, err, "failed to get log: error")
+}
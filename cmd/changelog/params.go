@@ -1,73 +1,434 @@
 package changelog
 
 import (
+	"errors"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	"github.com/gandarez/changelog-action/pkg/actions"
+	"github.com/gandarez/changelog-action/pkg/git"
 )
 
 type Params struct {
-	CurrentTag  string
-	PreviousTag string
-	Exclude     []string
-	RepoDir     string
-	Debug       bool
+	Command              string
+	CurrentTag           string
+	PreviousTag          string
+	BaseSHA              string
+	HeadSHA              string
+	Include              []string
+	Exclude              []string
+	IncludeAuthors       []string
+	ExcludeAuthors       []string
+	CollapseAuthors      bool
+	GroupDependencies    bool
+	GoModDiff            bool
+	GoModFiles           []string
+	IncludeBody          []string
+	ExcludeBody          []string
+	Paths                []string
+	ExcludePaths         []string
+	TagPrefix            string
+	TagPattern           string
+	PreviousTagStrategy  string
+	SkipPrereleases      bool
+	RepoDir              string
+	RepoURL              string
+	RepoProvider         string
+	ConfigFile           string
+	IssuePatterns        []string
+	Format               string
+	Template             string
+	Groups               []GroupConfig
+	NextVersion          bool
+	FullHistory          bool
+	ChangelogFile        string
+	ChangelogHeader      string
+	Output               string
+	Summary              bool
+	SummaryStatistics    bool
+	LintTypes            []string
+	LintScopes           []string
+	LintMaxSubjectLength int
+	Debug                bool
+	// Sources maps the input names to the source of their value, SourceDefault if missing.
+	Sources map[string]string
 }
 
-func LoadParams() (Params, error) {
-	var currentTag string
-
-	if currentTagStr := actions.GetInput("current_tag"); currentTagStr != "" {
-		currentTag = currentTagStr
+// LoadParams loads the params from the command line arguments, the action inputs and the
+// configuration file of the repository. Flags take precedence over inputs and inputs over the
+// configuration file. Every invalid argument is reported.
+func LoadParams(args []string) (Params, error) {
+	flags, err := parseFlags(args)
+	if err != nil {
+		return Params{}, err
 	}
 
-	var previousTag string
+	var (
+		cfg     config
+		sources = map[string]string{}
+	)
 
-	if previousTagStr := actions.GetInput("previous_tag"); previousTagStr != "" {
-		previousTag = previousTagStr
-	}
+	in := actions.NewInputs(func(name string) string {
+		if value, ok := flags[name]; ok {
+			sources[name] = SourceFlag
+			return value
+		}
 
-	var exclude []string
+		if value := actions.GetRawInput(name); strings.TrimSpace(value) != "" {
+			sources[name] = SourceInput
+			return value
+		}
 
-	if excludeArr := actions.GetInput("exclude"); excludeArr != "" {
-		exclude = strings.Split(excludeArr, "\n")
-	}
+		if value, ok := cfg.values[name]; ok {
+			sources[name] = SourceConfig
+			return value
+		}
+
+		return ""
+	})
 
 	var repoDir = "."
 
-	if repoDirStr := actions.GetInput("repo_dir"); repoDirStr != "" {
+	if repoDirStr := in.GetInput("repo_dir"); repoDirStr != "" {
 		repoDir = repoDirStr
 	}
 
-	var debug bool
+	cfg, err = loadConfig(repoDir, in.GetInput("config_file"))
+	if err != nil {
+		return Params{}, err
+	}
+
+	if len(cfg.groups) > 0 {
+		sources["groups"] = SourceConfig
+	}
+
+	// errs collects the errors of every argument, nil errors are discarded by errors.Join.
+	var errs []error
+
+	command, err := in.GetEnumInput("command", CommandChangelog, CommandChangelog, CommandLint)
+	errs = append(errs, err)
+
+	currentTag := in.GetInput("current_tag")
+	previousTag := in.GetInput("previous_tag")
 
-	if debugStr := actions.GetInput("debug"); debugStr != "" {
-		parsed, err := strconv.ParseBool(debugStr)
+	// On pull requests the range is the pull request commits unless the tags are set.
+	var baseSHA, headSHA string
+
+	if currentTag == "" && previousTag == "" {
+		pr, err := actions.GetPullRequest()
 		if err != nil {
-			return Params{}, fmt.Errorf("invalid debug argument: %s", debugStr)
+			errs = append(errs, fmt.Errorf("failed to get pull request: %s", err))
+		}
+
+		if pr != nil && pr.Base.SHA != "" && pr.Head.SHA != "" {
+			baseSHA, headSHA = pr.Base.SHA, pr.Head.SHA
+			sources["base_sha"], sources["head_sha"] = SourceEvent, SourceEvent
 		}
+	}
+
+	include, err := in.GetRegexpInput("include")
+	errs = append(errs, err)
+
+	exclude, err := in.GetRegexpInput("exclude")
+	errs = append(errs, err)
+
+	includeAuthors := in.GetMultilineInput("include_authors")
+	excludeAuthors := in.GetMultilineInput("exclude_authors")
+
+	collapseAuthors, err := in.GetBooleanInput("collapse_authors", false)
+	errs = append(errs, err)
+
+	groupDependencies, err := in.GetBooleanInput("group_dependencies", false)
+	errs = append(errs, err)
+
+	goModDiff, err := in.GetBooleanInput("go_mod_diff", false)
+	errs = append(errs, err)
+
+	goModFiles := in.GetMultilineInput("go_mod_files")
+
+	includeBody, err := in.GetRegexpInput("include_body")
+	errs = append(errs, err)
+
+	excludeBody, err := in.GetRegexpInput("exclude_body")
+	errs = append(errs, err)
+
+	paths := in.GetMultilineInput("paths")
+	excludePaths := in.GetMultilineInput("exclude_paths")
+	tagPrefix := in.GetInput("tag_prefix")
+
+	var tagPattern string
+
+	if tagPatternStr := in.GetInput("tag_pattern"); tagPatternStr != "" {
+		if _, err := path.Match(tagPatternStr, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid tag_pattern argument: %s", tagPatternStr))
+		}
+
+		tagPattern = tagPatternStr
+	}
+
+	previousTagStrategy, err := in.GetEnumInput("previous_tag_strategy", PreviousTagStrategyDescribe,
+		PreviousTagStrategyDescribe, PreviousTagStrategySemver)
+	errs = append(errs, err)
+
+	skipPrereleases, err := in.GetBooleanInput("skip_prereleases", false)
+	errs = append(errs, err)
+
+	var repoURL string
+
+	if repoURLStr := in.GetInput("repo_url"); repoURLStr != "" {
+		if _, err := git.ParseRepoURL(repoURLStr); err != nil {
+			errs = append(errs, fmt.Errorf("invalid repo_url argument: %s", repoURLStr))
+		}
+
+		repoURL = repoURLStr
+	}
+
+	repoProvider, err := in.GetEnumInput("repo_provider", "", string(git.ProviderGitHub),
+		string(git.ProviderGitLab), string(git.ProviderBitbucket), string(git.ProviderGitea))
+	errs = append(errs, err)
+
+	issuePatterns := in.GetMultilineInput("issue_patterns")
+
+	if _, err := parseIssuePatterns(issuePatterns); err != nil {
+		errs = append(errs, fmt.Errorf("invalid issue_patterns argument: %s", err))
+	}
+
+	format, err := in.GetEnumInput("format", FormatMarkdown, FormatMarkdown, FormatJSON, FormatText)
+	errs = append(errs, err)
+
+	template := in.GetInput("template")
+
+	nextVersion, err := in.GetBooleanInput("calculate_next_version", false)
+	errs = append(errs, err)
+
+	fullHistory, err := in.GetBooleanInput("full_history", false)
+	errs = append(errs, err)
+
+	changelogFile := in.GetInput("changelog_file")
+
+	var changelogHeader = DefaultChangelogHeader
+
+	if changelogHeaderStr := in.GetInput("changelog_header"); changelogHeaderStr != "" {
+		changelogHeader = changelogHeaderStr
+	}
+
+	output := in.GetInput("output")
 
-		debug = parsed
+	summary, err := in.GetBooleanInput("summary", false)
+	errs = append(errs, err)
+
+	summaryStatistics, err := in.GetBooleanInput("summary_statistics", false)
+	errs = append(errs, err)
+
+	var lintTypes = DefaultLintTypes()
+
+	if lintTypesArr := in.GetInput("lint_types"); lintTypesArr != "" {
+		lintTypes = splitList(lintTypesArr)
+	}
+
+	lintScopes := splitList(in.GetInput("lint_scopes"))
+
+	lintMaxSubjectLength, err := in.GetIntInput("lint_max_subject_length", DefaultLintMaxSubjectLength)
+	errs = append(errs, err)
+
+	if lintMaxSubjectLength < 0 {
+		errs = append(errs, fmt.Errorf("invalid lint_max_subject_length argument: %d, expected 0 or more",
+			lintMaxSubjectLength))
+	}
+
+	debug, err := in.GetBooleanInput("debug", false)
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return Params{}, err
 	}
 
 	return Params{
-		CurrentTag:  currentTag,
-		PreviousTag: previousTag,
-		Exclude:     exclude,
-		RepoDir:     repoDir,
-		Debug:       debug,
+		Command:              command,
+		CurrentTag:           currentTag,
+		PreviousTag:          previousTag,
+		BaseSHA:              baseSHA,
+		HeadSHA:              headSHA,
+		Include:              include,
+		Exclude:              exclude,
+		IncludeAuthors:       includeAuthors,
+		ExcludeAuthors:       excludeAuthors,
+		CollapseAuthors:      collapseAuthors,
+		GroupDependencies:    groupDependencies,
+		GoModDiff:            goModDiff,
+		GoModFiles:           goModFiles,
+		IncludeBody:          includeBody,
+		ExcludeBody:          excludeBody,
+		Paths:                paths,
+		ExcludePaths:         excludePaths,
+		TagPrefix:            tagPrefix,
+		TagPattern:           tagPattern,
+		PreviousTagStrategy:  previousTagStrategy,
+		SkipPrereleases:      skipPrereleases,
+		RepoDir:              repoDir,
+		RepoURL:              repoURL,
+		RepoProvider:         repoProvider,
+		ConfigFile:           cfg.file,
+		IssuePatterns:        issuePatterns,
+		Format:               format,
+		Template:             template,
+		Groups:               cfg.groups,
+		NextVersion:          nextVersion,
+		FullHistory:          fullHistory,
+		ChangelogFile:        changelogFile,
+		ChangelogHeader:      changelogHeader,
+		Output:               output,
+		Summary:              summary,
+		SummaryStatistics:    summaryStatistics,
+		LintTypes:            lintTypes,
+		LintScopes:           lintScopes,
+		LintMaxSubjectLength: lintMaxSubjectLength,
+		Debug:                debug,
+		Sources:              sources,
 	}, nil
 }
 
+// String returns the params with the source of each value.
 func (p Params) String() string {
-	return fmt.Sprintf(
-		"current tag: %q, previous tag: %q, exclude: %q, repo dir %q, debug: %t\n",
-		p.CurrentTag,
-		p.PreviousTag,
-		strings.Join(p.Exclude, ","),
-		p.RepoDir,
-		p.Debug,
-	)
+	fields := []struct {
+		label string
+		input string
+		value any
+	}{
+		{"command", "command", p.Command},
+		{"current tag", "current_tag", p.CurrentTag},
+		{"previous tag", "previous_tag", p.PreviousTag},
+		{"base sha", "base_sha", p.BaseSHA},
+		{"head sha", "head_sha", p.HeadSHA},
+		{"include", "include", p.Include},
+		{"exclude", "exclude", p.Exclude},
+		{"include authors", "include_authors", p.IncludeAuthors},
+		{"exclude authors", "exclude_authors", p.ExcludeAuthors},
+		{"collapse authors", "collapse_authors", p.CollapseAuthors},
+		{"group dependencies", "group_dependencies", p.GroupDependencies},
+		{"go mod diff", "go_mod_diff", p.GoModDiff},
+		{"go mod files", "go_mod_files", p.GoModFiles},
+		{"include body", "include_body", p.IncludeBody},
+		{"exclude body", "exclude_body", p.ExcludeBody},
+		{"paths", "paths", p.Paths},
+		{"exclude paths", "exclude_paths", p.ExcludePaths},
+		{"tag prefix", "tag_prefix", p.TagPrefix},
+		{"tag pattern", "tag_pattern", p.TagPattern},
+		{"previous tag strategy", "previous_tag_strategy", p.PreviousTagStrategy},
+		{"skip prereleases", "skip_prereleases", p.SkipPrereleases},
+		{"repo dir", "repo_dir", p.RepoDir},
+		{"repo url", "repo_url", p.RepoURL},
+		{"repo provider", "repo_provider", p.RepoProvider},
+		{"config file", "config_file", p.ConfigFile},
+		{"issue patterns", "issue_patterns", p.IssuePatterns},
+		{"format", "format", p.Format},
+		{"template", "template", p.Template},
+		{"groups", "groups", p.groupTitles()},
+		{"next version", "calculate_next_version", p.NextVersion},
+		{"full history", "full_history", p.FullHistory},
+		{"changelog file", "changelog_file", p.ChangelogFile},
+		{"changelog header", "changelog_header", p.ChangelogHeader},
+		{"output", "output", p.Output},
+		{"summary", "summary", p.Summary},
+		{"summary statistics", "summary_statistics", p.SummaryStatistics},
+		{"lint types", "lint_types", p.LintTypes},
+		{"lint scopes", "lint_scopes", p.LintScopes},
+		{"lint max subject length", "lint_max_subject_length", p.LintMaxSubjectLength},
+		{"debug", "debug", p.Debug},
+	}
+
+	elements := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		value := fmt.Sprint(f.value)
+
+		switch v := f.value.(type) {
+		case string:
+			value = strconv.Quote(v)
+		case []string:
+			value = strconv.Quote(strings.Join(v, ","))
+		}
+
+		elements = append(elements, fmt.Sprintf("%s: %s (%s)", f.label, value, p.source(f.input)))
+	}
+
+	return strings.Join(elements, ", ") + "\n"
+}
+
+// source returns the source of the input value.
+func (p Params) source(input string) string {
+	if source, ok := p.Sources[input]; ok {
+		return source
+	}
+
+	return SourceDefault
+}
+
+// groupTitles returns the titles of the configured groups.
+func (p Params) groupTitles() []string {
+	titles := make([]string, 0, len(p.Groups))
+
+	for _, g := range p.Groups {
+		titles = append(titles, g.Title)
+	}
+
+	return titles
+}
+
+// sections returns the changelog sections, the configured groups in order or the default ones.
+func (p Params) sections() []section {
+	if len(p.Groups) == 0 {
+		return defaultSections()
+	}
+
+	sections := make([]section, 0, len(p.Groups))
+
+	for _, g := range p.Groups {
+		sections = append(sections, section{title: g.Title, types: g.Types})
+	}
+
+	return sections
+}
+
+// splitList splits a list given one item per line or comma separated.
+func splitList(value string) []string {
+	var items []string
+
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == '\n' || r == ',' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// tagGlob returns the glob restricting tag discovery. The tag pattern takes precedence
+// over the tag prefix.
+func (p Params) tagGlob() string {
+	if p.TagPattern != "" {
+		return p.TagPattern
+	}
+
+	if p.TagPrefix != "" {
+		return p.TagPrefix + "*"
+	}
+
+	return ""
+}
+
+// versionPrefix returns the part of the tags preceding the semantic version. When only
+// a tag pattern is set it's the literal part before the first wildcard.
+func (p Params) versionPrefix() string {
+	if p.TagPrefix != "" || p.TagPattern == "" {
+		return p.TagPrefix
+	}
+
+	if i := strings.IndexAny(p.TagPattern, "*?[\\"); i >= 0 {
+		return p.TagPattern[:i]
+	}
+
+	return p.TagPattern
 }
@@ -0,0 +1,39 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParams_TagGlob(t *testing.T) {
+	tests := map[string]struct {
+		Params         Params
+		ExpectedGlob   string
+		ExpectedPrefix string
+	}{
+		"none": {},
+		"tag prefix": {
+			Params:         Params{TagPrefix: "api/"},
+			ExpectedGlob:   "api/*",
+			ExpectedPrefix: "api/",
+		},
+		"tag pattern": {
+			Params:         Params{TagPattern: "worker/v[0-9]*"},
+			ExpectedGlob:   "worker/v[0-9]*",
+			ExpectedPrefix: "worker/v",
+		},
+		"tag prefix and pattern": {
+			Params:         Params{TagPrefix: "worker/", TagPattern: "worker/v[0-9]*"},
+			ExpectedGlob:   "worker/v[0-9]*",
+			ExpectedPrefix: "worker/",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.ExpectedGlob, test.Params.tagGlob())
+			assert.Equal(t, test.ExpectedPrefix, test.Params.versionPrefix())
+		})
+	}
+}
@@ -2,19 +2,22 @@ package changelog_test
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gandarez/changelog-action/cmd/changelog"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadParams_CurrentTag(t *testing.T) {
 	os.Setenv("INPUT_CURRENT_TAG", "v1.2.3")
 	defer os.Unsetenv("INPUT_CURRENT_TAG")
 
-	params, err := changelog.LoadParams()
+	params, err := changelog.LoadParams(nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, "v1.2.3", params.CurrentTag)
@@ -24,37 +27,400 @@ func TestLoadParams_PreviousTag(t *testing.T) {
 	os.Setenv("INPUT_PREVIOUS_TAG", "v0.2.3")
 	defer os.Unsetenv("INPUT_PREVIOUS_TAG")
 
-	params, err := changelog.LoadParams()
+	params, err := changelog.LoadParams(nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, "v0.2.3", params.PreviousTag)
 }
 
+func TestLoadParams_PullRequest(t *testing.T) {
+	t.Setenv("GITHUB_EVENT_NAME", "pull_request")
+	t.Setenv("GITHUB_EVENT_PATH", writeEvent(t, `{"pull_request": {"base": {"sha": "6dcb09b"}, "head": {"sha": "e5bd391"}}}`))
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "6dcb09b", params.BaseSHA)
+	assert.Equal(t, "e5bd391", params.HeadSHA)
+}
+
+func TestLoadParams_PullRequestTagSet(t *testing.T) {
+	t.Setenv("GITHUB_EVENT_NAME", "pull_request")
+	t.Setenv("GITHUB_EVENT_PATH", writeEvent(t, `{"pull_request": {"base": {"sha": "6dcb09b"}, "head": {"sha": "e5bd391"}}}`))
+	t.Setenv("INPUT_CURRENT_TAG", "v1.2.3")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, params.BaseSHA)
+	assert.Empty(t, params.HeadSHA)
+}
+
+func TestLoadParams_PullRequestErr(t *testing.T) {
+	t.Setenv("GITHUB_EVENT_NAME", "pull_request")
+	t.Setenv("GITHUB_EVENT_PATH", writeEvent(t, `{`))
+
+	_, err := changelog.LoadParams(nil)
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to get pull request: failed to parse github event")
+}
+
 func TestLoadParams_Exclude(t *testing.T) {
 	os.Setenv("INPUT_EXCLUDE", "^Merge .*\nFix .*")
 	defer os.Unsetenv("INPUT_EXCLUDE")
 
-	params, err := changelog.LoadParams()
+	params, err := changelog.LoadParams(nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, []string{"^Merge .*", "Fix .*"}, params.Exclude)
 }
 
+func TestLoadParams_ExcludeComments(t *testing.T) {
+	os.Setenv("INPUT_EXCLUDE", "# merge commits\n^Merge .*\n\n  #\\d+ closed  ")
+	defer os.Unsetenv("INPUT_EXCLUDE")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"^Merge .*", "#\\d+ closed"}, params.Exclude)
+}
+
+func TestLoadParams_ExcludeErr(t *testing.T) {
+	os.Setenv("INPUT_EXCLUDE", "^Merge .*\n(unclosed")
+	defer os.Unsetenv("INPUT_EXCLUDE")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid exclude argument: line 2: error parsing regexp: missing closing ): `(unclosed`")
+}
+
+func TestLoadParams_ExcludeErrLeadingBlankLines(t *testing.T) {
+	os.Setenv("INPUT_EXCLUDE", "\n\n^ok\n(bad")
+	defer os.Unsetenv("INPUT_EXCLUDE")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid exclude argument: line 4: error parsing regexp: missing closing ): `(bad`")
+}
+
+func TestLoadParams_Errs(t *testing.T) {
+	os.Setenv("INPUT_DEBUG", "yes please")
+	defer os.Unsetenv("INPUT_DEBUG")
+
+	os.Setenv("INPUT_INCLUDE_BODY", "[a-\nBREAKING\n*")
+	defer os.Unsetenv("INPUT_INCLUDE_BODY")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid include_body argument: line 1: error parsing regexp: missing closing ]: `[a-`\n"+
+		"invalid include_body argument: line 3: error parsing regexp: missing argument to repetition operator: `*`\n"+
+		"invalid debug argument: yes please, expected true or false")
+}
+
+func TestLoadParams_Include(t *testing.T) {
+	os.Setenv("INPUT_INCLUDE", "^(feat|fix|perf)")
+	defer os.Unsetenv("INPUT_INCLUDE")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"^(feat|fix|perf)"}, params.Include)
+}
+
+func TestLoadParams_AuthorsAndBody(t *testing.T) {
+	os.Setenv("INPUT_INCLUDE_AUTHORS", "@example.com$")
+	defer os.Unsetenv("INPUT_INCLUDE_AUTHORS")
+	os.Setenv("INPUT_EXCLUDE_AUTHORS", "^bot\nnoreply")
+	defer os.Unsetenv("INPUT_EXCLUDE_AUTHORS")
+	os.Setenv("INPUT_INCLUDE_BODY", "Changelog: yes")
+	defer os.Unsetenv("INPUT_INCLUDE_BODY")
+	os.Setenv("INPUT_EXCLUDE_BODY", "\\[skip changelog\\]")
+	defer os.Unsetenv("INPUT_EXCLUDE_BODY")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"@example.com$"}, params.IncludeAuthors)
+	assert.Equal(t, []string{"^bot", "noreply"}, params.ExcludeAuthors)
+	assert.Equal(t, []string{"Changelog: yes"}, params.IncludeBody)
+	assert.Equal(t, []string{"\\[skip changelog\\]"}, params.ExcludeBody)
+}
+
+func TestLoadParams_CollapseAuthors(t *testing.T) {
+	os.Setenv("INPUT_COLLAPSE_AUTHORS", "true")
+	defer os.Unsetenv("INPUT_COLLAPSE_AUTHORS")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.True(t, params.CollapseAuthors)
+}
+
+func TestLoadParams_CollapseAuthorsErr(t *testing.T) {
+	os.Setenv("INPUT_COLLAPSE_AUTHORS", "invalid")
+	defer os.Unsetenv("INPUT_COLLAPSE_AUTHORS")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid collapse_authors argument: invalid, expected true or false")
+}
+
+func TestLoadParams_GroupDependencies(t *testing.T) {
+	os.Setenv("INPUT_GROUP_DEPENDENCIES", "true")
+	defer os.Unsetenv("INPUT_GROUP_DEPENDENCIES")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.True(t, params.GroupDependencies)
+}
+
+func TestLoadParams_GoModDiff(t *testing.T) {
+	os.Setenv("INPUT_GO_MOD_DIFF", "true")
+	defer os.Unsetenv("INPUT_GO_MOD_DIFF")
+	os.Setenv("INPUT_GO_MOD_FILES", "go.mod\ntools/go.mod")
+	defer os.Unsetenv("INPUT_GO_MOD_FILES")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.True(t, params.GoModDiff)
+	assert.Equal(t, []string{"go.mod", "tools/go.mod"}, params.GoModFiles)
+}
+
+func TestLoadParams_GoModDiffErr(t *testing.T) {
+	os.Setenv("INPUT_GO_MOD_DIFF", "invalid")
+	defer os.Unsetenv("INPUT_GO_MOD_DIFF")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid go_mod_diff argument: invalid, expected true or false")
+}
+
+func TestLoadParams_Summary(t *testing.T) {
+	os.Setenv("INPUT_SUMMARY", "true")
+	defer os.Unsetenv("INPUT_SUMMARY")
+	os.Setenv("INPUT_SUMMARY_STATISTICS", "true")
+	defer os.Unsetenv("INPUT_SUMMARY_STATISTICS")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.True(t, params.Summary)
+	assert.True(t, params.SummaryStatistics)
+}
+
+func TestLoadParams_SummaryErr(t *testing.T) {
+	os.Setenv("INPUT_SUMMARY", "invalid")
+	defer os.Unsetenv("INPUT_SUMMARY")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid summary argument: invalid, expected true or false")
+}
+
+func TestLoadParams_Paths(t *testing.T) {
+	os.Setenv("INPUT_PATHS", "services/api\nlibs/common")
+	defer os.Unsetenv("INPUT_PATHS")
+
+	os.Setenv("INPUT_EXCLUDE_PATHS", "services/api/docs")
+	defer os.Unsetenv("INPUT_EXCLUDE_PATHS")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"services/api", "libs/common"}, params.Paths)
+	assert.Equal(t, []string{"services/api/docs"}, params.ExcludePaths)
+}
+
+func TestLoadParams_TagPrefix(t *testing.T) {
+	os.Setenv("INPUT_TAG_PREFIX", "api/")
+	defer os.Unsetenv("INPUT_TAG_PREFIX")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "api/", params.TagPrefix)
+}
+
+func TestLoadParams_TagPattern(t *testing.T) {
+	os.Setenv("INPUT_TAG_PATTERN", "worker/v*")
+	defer os.Unsetenv("INPUT_TAG_PATTERN")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "worker/v*", params.TagPattern)
+}
+
+func TestLoadParams_TagPatternErr(t *testing.T) {
+	os.Setenv("INPUT_TAG_PATTERN", "worker/[v*")
+	defer os.Unsetenv("INPUT_TAG_PATTERN")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid tag_pattern argument: worker/[v*")
+}
+
+func TestLoadParams_PreviousTagStrategy(t *testing.T) {
+	os.Setenv("INPUT_PREVIOUS_TAG_STRATEGY", "semver")
+	defer os.Unsetenv("INPUT_PREVIOUS_TAG_STRATEGY")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "semver", params.PreviousTagStrategy)
+}
+
+func TestLoadParams_PreviousTagStrategyErr(t *testing.T) {
+	os.Setenv("INPUT_PREVIOUS_TAG_STRATEGY", "latest")
+	defer os.Unsetenv("INPUT_PREVIOUS_TAG_STRATEGY")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid previous_tag_strategy argument: latest, expected one of: describe, semver")
+}
+
+func TestLoadParams_SkipPrereleases(t *testing.T) {
+	os.Setenv("INPUT_SKIP_PRERELEASES", "true")
+	defer os.Unsetenv("INPUT_SKIP_PRERELEASES")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.True(t, params.SkipPrereleases)
+}
+
 func TestLoadParams_RepoDir(t *testing.T) {
 	os.Setenv("INPUT_REPO_DIR", "/var/tmp/folder")
 	defer os.Unsetenv("INPUT_REPO_DIR")
 
-	params, err := changelog.LoadParams()
+	params, err := changelog.LoadParams(nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, "/var/tmp/folder", params.RepoDir)
 }
 
+func TestLoadParams_RepoURL(t *testing.T) {
+	os.Setenv("INPUT_REPO_URL", "https://git.example.com/team/repo")
+	defer os.Unsetenv("INPUT_REPO_URL")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://git.example.com/team/repo", params.RepoURL)
+}
+
+func TestLoadParams_RepoURLErr(t *testing.T) {
+	os.Setenv("INPUT_REPO_URL", "git.example.com/team/repo")
+	defer os.Unsetenv("INPUT_REPO_URL")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid repo_url argument: git.example.com/team/repo")
+}
+
+func TestLoadParams_RepoProvider(t *testing.T) {
+	os.Setenv("INPUT_REPO_PROVIDER", "gitlab")
+	defer os.Unsetenv("INPUT_REPO_PROVIDER")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "gitlab", params.RepoProvider)
+}
+
+func TestLoadParams_RepoProviderErr(t *testing.T) {
+	os.Setenv("INPUT_REPO_PROVIDER", "sourcehut")
+	defer os.Unsetenv("INPUT_REPO_PROVIDER")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid repo_provider argument: sourcehut, expected one of: github, gitlab, bitbucket, gitea")
+}
+
+func TestLoadParams_IssuePatterns(t *testing.T) {
+	os.Setenv("INPUT_ISSUE_PATTERNS",
+		"JIRA-\\d+ => https://jira.example.com/browse/$0\nGH-(\\d+) => https://github.com/owner/repo/issues/$1")
+	defer os.Unsetenv("INPUT_ISSUE_PATTERNS")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"JIRA-\\d+ => https://jira.example.com/browse/$0",
+		"GH-(\\d+) => https://github.com/owner/repo/issues/$1",
+	}, params.IssuePatterns)
+}
+
+func TestLoadParams_IssuePatternsErr(t *testing.T) {
+	os.Setenv("INPUT_ISSUE_PATTERNS", "JIRA-\\d+")
+	defer os.Unsetenv("INPUT_ISSUE_PATTERNS")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid issue_patterns argument: invalid issue pattern, expected `<regexp> => <url>`: JIRA-\\d+")
+}
+
+func TestLoadParams_Format(t *testing.T) {
+	os.Setenv("INPUT_FORMAT", "json")
+	defer os.Unsetenv("INPUT_FORMAT")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "json", params.Format)
+}
+
+func TestLoadParams_FormatErr(t *testing.T) {
+	os.Setenv("INPUT_FORMAT", "yaml")
+	defer os.Unsetenv("INPUT_FORMAT")
+
+	_, err := changelog.LoadParams(nil)
+
+	assert.EqualError(t, err, "invalid format argument: yaml, expected one of: markdown, json, text")
+}
+
+func TestLoadParams_Template(t *testing.T) {
+	os.Setenv("INPUT_TEMPLATE", ".github/changelog.tmpl")
+	defer os.Unsetenv("INPUT_TEMPLATE")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, ".github/changelog.tmpl", params.Template)
+}
+
+func TestLoadParams_NextVersion(t *testing.T) {
+	os.Setenv("INPUT_CALCULATE_NEXT_VERSION", "true")
+	defer os.Unsetenv("INPUT_CALCULATE_NEXT_VERSION")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.True(t, params.NextVersion)
+}
+
+func TestLoadParams_ChangelogFile(t *testing.T) {
+	os.Setenv("INPUT_CHANGELOG_FILE", "CHANGELOG.md")
+	defer os.Unsetenv("INPUT_CHANGELOG_FILE")
+
+	os.Setenv("INPUT_CHANGELOG_HEADER", "# Release Notes")
+	defer os.Unsetenv("INPUT_CHANGELOG_HEADER")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "CHANGELOG.md", params.ChangelogFile)
+	assert.Equal(t, "# Release Notes", params.ChangelogHeader)
+}
+
 func TestLoadParams_Debug(t *testing.T) {
 	os.Setenv("INPUT_DEBUG", "true")
 	defer os.Unsetenv("INPUT_DEBUG")
 
-	params, err := changelog.LoadParams()
+	params, err := changelog.LoadParams(nil)
 	require.NoError(t, err)
 
 	assert.True(t, params.Debug)
@@ -64,7 +430,278 @@ func TestLoadParams_DebugErr(t *testing.T) {
 	os.Setenv("INPUT_DEBUG", "10")
 	defer os.Unsetenv("INPUT_DEBUG")
 
-	_, err := changelog.LoadParams()
+	_, err := changelog.LoadParams(nil)
 
 	assert.Error(t, err)
 }
+
+func TestLoadParams_Flags(t *testing.T) {
+	os.Setenv("INPUT_CURRENT_TAG", "v1.2.3")
+	defer os.Unsetenv("INPUT_CURRENT_TAG")
+
+	os.Setenv("INPUT_PREVIOUS_TAG", "v1.2.2")
+	defer os.Unsetenv("INPUT_PREVIOUS_TAG")
+
+	params, err := changelog.LoadParams([]string{
+		"--current-tag", "v2.0.0",
+		"--exclude", "^Merge .*",
+		"--exclude=Fix .*",
+		"--repo-dir", "/var/tmp/folder",
+		"--format", "json",
+		"--output", "CHANGES.json",
+		"--debug",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, changelog.Params{
+		Command:              "changelog",
+		CurrentTag:           "v2.0.0",
+		PreviousTag:          "v1.2.2",
+		Exclude:              []string{"^Merge .*", "Fix .*"},
+		PreviousTagStrategy:  "describe",
+		RepoDir:              "/var/tmp/folder",
+		Format:               "json",
+		ChangelogHeader:      "# Changelog",
+		Output:               "CHANGES.json",
+		LintTypes:            changelog.DefaultLintTypes(),
+		LintMaxSubjectLength: 100,
+		Debug:                true,
+		Sources: map[string]string{
+			"current_tag":  changelog.SourceFlag,
+			"previous_tag": changelog.SourceInput,
+			"exclude":      changelog.SourceFlag,
+			"repo_dir":     changelog.SourceFlag,
+			"format":       changelog.SourceFlag,
+			"output":       changelog.SourceFlag,
+			"debug":        changelog.SourceFlag,
+		},
+	}, params)
+}
+
+func TestLoadParams_Command(t *testing.T) {
+	params, err := changelog.LoadParams([]string{
+		"lint",
+		"--lint-types", "feat",
+		"--lint-types=fix",
+		"--lint-max-subject-length", "0",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "lint", params.Command)
+	assert.Equal(t, []string{"feat", "fix"}, params.LintTypes)
+	assert.Zero(t, params.LintMaxSubjectLength)
+}
+
+func TestLoadParams_CommandInput(t *testing.T) {
+	os.Setenv("INPUT_COMMAND", "lint")
+	defer os.Unsetenv("INPUT_COMMAND")
+	os.Setenv("INPUT_LINT_SCOPES", "api, cli\ndb")
+	defer os.Unsetenv("INPUT_LINT_SCOPES")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "lint", params.Command)
+	assert.Equal(t, []string{"api", "cli", "db"}, params.LintScopes)
+}
+
+func TestLoadParams_Config(t *testing.T) {
+	repoDir := t.TempDir()
+
+	writeConfig(t, filepath.Join(repoDir, ".github", "changelog.yml"), `
+groups:
+  - title: Features
+    types: [feat]
+  - title: Security
+    types: [sec]
+  - title: Others
+exclude:
+  - ^Merge .*
+  - ^chore\(release\)
+tag_pattern: api/v*
+format: json
+summary: true
+`)
+
+	os.Setenv("INPUT_REPO_DIR", repoDir)
+	defer os.Unsetenv("INPUT_REPO_DIR")
+
+	os.Setenv("INPUT_FORMAT", "text")
+	defer os.Unsetenv("INPUT_FORMAT")
+
+	params, err := changelog.LoadParams(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, ".github/changelog.yml", params.ConfigFile)
+	assert.Equal(t, []changelog.GroupConfig{
+		{Title: "Features", Types: []string{"feat"}},
+		{Title: "Security", Types: []string{"sec"}},
+		{Title: "Others"},
+	}, params.Groups)
+	assert.Equal(t, []string{"^Merge .*", `^chore\(release\)`}, params.Exclude)
+	assert.Equal(t, "api/v*", params.TagPattern)
+	assert.Equal(t, "text", params.Format)
+	assert.True(t, params.Summary)
+
+	assert.Contains(t, params.String(), `exclude: "^Merge .*,^chore\\(release\\)" (config)`)
+	assert.Contains(t, params.String(), `format: "text" (input)`)
+	assert.Contains(t, params.String(), `groups: "Features,Security,Others" (config)`)
+	assert.Contains(t, params.String(), `debug: false (default)`)
+}
+
+func TestLoadParams_ConfigActionDefaults(t *testing.T) {
+	data, err := os.ReadFile("../../action.yml")
+	require.NoError(t, err)
+
+	var action struct {
+		Inputs map[string]struct {
+			Default *string `yaml:"default"`
+		} `yaml:"inputs"`
+	}
+
+	err = yaml.Unmarshal(data, &action)
+	require.NoError(t, err)
+
+	// The runner sets an input for every default of action.yml.
+	for name, input := range action.Inputs {
+		if input.Default != nil {
+			t.Setenv("INPUT_"+strings.ToUpper(name), *input.Default)
+		}
+	}
+
+	repoDir := t.TempDir()
+
+	writeConfig(t, filepath.Join(repoDir, ".changelog.yml"), `
+format: json
+collapse_authors: true
+previous_tag_strategy: semver
+changelog_header: "# Release notes"
+summary: true
+lint_types: [feat, fix]
+lint_max_subject_length: 72
+`)
+
+	params, err := changelog.LoadParams([]string{"--repo-dir", repoDir})
+	require.NoError(t, err)
+
+	assert.Equal(t, "json", params.Format)
+	assert.True(t, params.CollapseAuthors)
+	assert.Equal(t, "semver", params.PreviousTagStrategy)
+	assert.Equal(t, "# Release notes", params.ChangelogHeader)
+	assert.True(t, params.Summary)
+	assert.Equal(t, []string{"feat", "fix"}, params.LintTypes)
+	assert.Equal(t, 72, params.LintMaxSubjectLength)
+
+	for _, name := range []string{
+		"format", "collapse_authors", "previous_tag_strategy", "changelog_header", "summary",
+		"lint_types", "lint_max_subject_length",
+	} {
+		assert.Equal(t, changelog.SourceConfig, params.Sources[name], name)
+	}
+}
+
+func TestLoadParams_ConfigFile(t *testing.T) {
+	repoDir := t.TempDir()
+
+	writeConfig(t, filepath.Join(repoDir, "changelog.yaml"), "tag_prefix: worker/\n")
+
+	params, err := changelog.LoadParams([]string{"--repo-dir", repoDir, "--config-file", "changelog.yaml"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "changelog.yaml", params.ConfigFile)
+	assert.Equal(t, "worker/", params.TagPrefix)
+	assert.Equal(t, changelog.SourceConfig, params.Sources["tag_prefix"])
+}
+
+func TestLoadParams_ConfigErr(t *testing.T) {
+	tests := map[string]struct {
+		Config   string
+		Expected string
+	}{
+		"unknown key": {
+			Config: "groups:\n  - types: [feat]\ncurrent_tag: v1.0.0\nexclude: [^Merge]\n",
+			Expected: "invalid config file .changelog.yml: group 1 has no title\n" +
+				"unknown key current_tag",
+		},
+		"invalid value": {
+			Config:   "exclude:\n  pattern: ^Merge\n",
+			Expected: "invalid config file .changelog.yml: invalid exclude value, expected a value or a list of values",
+		},
+		"invalid argument": {
+			Config:   "summary: maybe\n",
+			Expected: "invalid summary argument: maybe, expected true or false",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repoDir := t.TempDir()
+
+			writeConfig(t, filepath.Join(repoDir, ".changelog.yml"), test.Config)
+
+			_, err := changelog.LoadParams([]string{"--repo-dir", repoDir})
+
+			assert.EqualError(t, err, test.Expected)
+		})
+	}
+}
+
+func TestLoadParams_ConfigFileMissing(t *testing.T) {
+	_, err := changelog.LoadParams([]string{"--repo-dir", t.TempDir(), "--config-file", "changelog.yml"})
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to read config file")
+}
+
+func TestLoadParams_FlagsErr(t *testing.T) {
+	tests := map[string]struct {
+		Args     []string
+		Expected string
+	}{
+		"unknown flag": {
+			Args:     []string{"--unknown"},
+			Expected: "flag provided but not defined: -unknown",
+		},
+		"unexpected argument": {
+			Args:     []string{"--current-tag", "v1.0.0", "v0.9.0"},
+			Expected: "unexpected argument: v0.9.0",
+		},
+		"invalid format": {
+			Args:     []string{"--format", "yaml"},
+			Expected: "invalid format argument: yaml, expected one of: markdown, json, text",
+		},
+		"invalid command": {
+			Args:     []string{"release"},
+			Expected: "invalid command argument: release, expected one of: changelog, lint",
+		},
+		"invalid lint max subject length": {
+			Args:     []string{"lint", "--lint-max-subject-length", "-1"},
+			Expected: "invalid lint_max_subject_length argument: -1, expected 0 or more",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := changelog.LoadParams(test.Args)
+
+			assert.EqualError(t, err, test.Expected)
+		})
+	}
+}
+
+func writeEvent(t *testing.T, payload string) string {
+	fp := filepath.Join(t.TempDir(), "event.json")
+
+	err := os.WriteFile(fp, []byte(payload), 0600)
+	require.NoError(t, err)
+
+	return fp
+}
+
+func writeConfig(t *testing.T, fp, content string) {
+	err := os.MkdirAll(filepath.Dir(fp), 0750)
+	require.NoError(t, err)
+
+	err = os.WriteFile(fp, []byte(content), 0600)
+	require.NoError(t, err)
+}
@@ -0,0 +1,432 @@
+package changelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// FormatMarkdown renders the changelog as markdown.
+	FormatMarkdown = "markdown"
+	// FormatJSON renders the changelog as JSON.
+	FormatJSON = "json"
+	// FormatText renders the changelog as plain text.
+	FormatText = "text"
+)
+
+// Release holds the data of a single changelog release.
+type Release struct {
+	Version            string       `json:"version"`
+	PreviousVersion    string       `json:"previous_version"`
+	Range              string       `json:"range"`
+	NextVersion        string       `json:"next_version,omitempty"`
+	Date               time.Time    `json:"date"`
+	CompareURL         string       `json:"compare_url,omitempty"`
+	Issues             []Issue      `json:"issues,omitempty"`
+	HasBreakingChanges bool         `json:"has_breaking_changes"`
+	Commits            []Commit     `json:"commits"`
+	Dependencies       []Dependency `json:"dependencies,omitempty"`
+	GoModules          []GoModDiff  `json:"go_modules,omitempty"`
+	DependencyUpdates  []Commit     `json:"dependency_updates,omitempty"`
+	BreakingChanges    []Commit     `json:"-"`
+	Groups             []Group      `json:"-"`
+}
+
+type renderer interface {
+	Render(release Release) (string, error)
+}
+
+// multiRenderer is implemented by renderers having a dedicated representation for several releases.
+type multiRenderer interface {
+	RenderAll(releases []Release) (string, error)
+}
+
+// renderReleases renders releases, given from oldest to newest, with the newest first. A single
+// release is rendered as is unless fullHistory is set, so that e.g. the JSON output of the full
+// history is always an array.
+func renderReleases(r renderer, releases []Release, fullHistory bool) (string, error) {
+	if len(releases) == 1 && !fullHistory {
+		return r.Render(releases[0])
+	}
+
+	newestFirst := make([]Release, 0, len(releases))
+
+	for i := len(releases) - 1; i >= 0; i-- {
+		newestFirst = append(newestFirst, releases[i])
+	}
+
+	if mr, ok := r.(multiRenderer); ok {
+		return mr.RenderAll(newestFirst)
+	}
+
+	elements := make([]string, 0, len(newestFirst))
+
+	for _, release := range newestFirst {
+		output, err := r.Render(release)
+		if err != nil {
+			return "", err
+		}
+
+		elements = append(elements, strings.TrimSpace(output))
+	}
+
+	return strings.Join(elements, "\n\n"), nil
+}
+
+// newRenderer returns the renderer for the given params. A template takes precedence over
+// the format and markdown is used when format is empty.
+func newRenderer(params Params) (renderer, error) {
+	if params.Template != "" {
+		text, err := loadTemplate(params.RepoDir, params.Template)
+		if err != nil {
+			return nil, err
+		}
+
+		return newTemplateRenderer(text)
+	}
+
+	switch format := params.Format; format {
+	case "", FormatMarkdown:
+		return markdownRenderer{versionHeading: params.FullHistory}, nil
+	case FormatJSON:
+		return jsonRenderer{}, nil
+	case FormatText:
+		return textRenderer{versionHeading: params.FullHistory}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+type markdownRenderer struct {
+	// versionHeading titles the release with its version and date instead of "Changelog".
+	versionHeading bool
+}
+
+// Render renders the release as markdown with one section per group.
+func (r markdownRenderer) Render(release Release) (string, error) {
+	elements := []string{"## Changelog"}
+
+	if r.versionHeading {
+		elements[0] = fmt.Sprintf("## %s (%s)", release.Version, release.Date.Format("2006-01-02"))
+	}
+
+	if len(release.BreakingChanges) > 0 {
+		lines := make([]string, 0, len(release.BreakingChanges))
+
+		for _, commit := range release.BreakingChanges {
+			line := markdownCommit(commit)
+
+			if commit.BreakingChange != "" {
+				line += "\n\n" + indent(commit.BreakingChange, "  ")
+			}
+
+			lines = append(lines, line)
+		}
+
+		elements = append(elements, "### Breaking Changes", strings.Join(lines, "\n"))
+	}
+
+	for _, group := range release.Groups {
+		lines := make([]string, 0, len(group.Commits))
+
+		for _, commit := range group.Commits {
+			lines = append(lines, markdownCommit(commit))
+		}
+
+		elements = append(elements, "### "+group.Title, strings.Join(lines, "\n"))
+	}
+
+	if len(release.Dependencies) > 0 {
+		lines := []string{"| Package | From | To |", "| --- | --- | --- |"}
+
+		for _, dep := range release.Dependencies {
+			lines = append(lines, fmt.Sprintf("| %s | %s | %s |", dep.Name, dep.From, dep.To))
+		}
+
+		elements = append(elements, "### Dependencies", strings.Join(lines, "\n"))
+	}
+
+	if len(release.GoModules) > 0 {
+		blocks := []string{"### Go Modules"}
+
+		for _, diff := range release.GoModules {
+			var lines []string
+
+			if showGoModFile(release.GoModules) {
+				lines = append(lines, "**"+diff.File+"**\n")
+			}
+
+			for _, line := range diff.lines() {
+				lines = append(lines, "- "+line)
+			}
+
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		}
+
+		elements = append(elements, blocks...)
+	}
+
+	if len(release.DependencyUpdates) > 0 {
+		elements = append(elements, fmt.Sprintf("**Dependency updates**: %s", dependencyUpdatesSummary(release)))
+	}
+
+	if release.CompareURL != "" {
+		elements = append(elements, fmt.Sprintf("**Full Changelog**: [%s...%s](%s)",
+			release.PreviousVersion, release.Version, release.CompareURL))
+	}
+
+	return strings.Join(elements, "\n\n"), nil
+}
+
+// markdownCommit renders a commit as a list item, linking its hash when the commit has an url.
+func markdownCommit(commit Commit) string {
+	line := "- " + commit.ShortHash + " "
+
+	if commit.URL != "" {
+		line = "- [" + commit.ShortHash + "](" + commit.URL + ") "
+	}
+
+	if commit.Scope != "" {
+		line += "**" + commit.Scope + ":** "
+	}
+
+	return line + markdownSubject(commit)
+}
+
+type jsonRenderer struct{}
+
+// Render renders the release as indented JSON.
+func (jsonRenderer) Render(release Release) (string, error) {
+	if release.Commits == nil {
+		release.Commits = []Commit{}
+	}
+
+	data, err := json.MarshalIndent(release, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal release: %s", err)
+	}
+
+	return string(data), nil
+}
+
+// RenderAll renders the releases as an indented JSON array.
+func (jsonRenderer) RenderAll(releases []Release) (string, error) {
+	for i := range releases {
+		if releases[i].Commits == nil {
+			releases[i].Commits = []Commit{}
+		}
+	}
+
+	data, err := json.MarshalIndent(releases, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal releases: %s", err)
+	}
+
+	return string(data), nil
+}
+
+type textRenderer struct {
+	// versionHeading titles the release with its version instead of "Changelog".
+	versionHeading bool
+}
+
+// Render renders the release as plain text with one section per group.
+func (r textRenderer) Render(release Release) (string, error) {
+	elements := []string{"Changelog"}
+
+	if r.versionHeading {
+		elements[0] = release.Version
+	}
+
+	if len(release.BreakingChanges) > 0 {
+		lines := []string{"Breaking Changes"}
+
+		for _, commit := range release.BreakingChanges {
+			line := "  " + commit.ShortHash + " "
+
+			if commit.Scope != "" {
+				line += commit.Scope + ": "
+			}
+
+			line += commit.Subject
+
+			if commit.BreakingChange != "" {
+				line += "\n" + indent(commit.BreakingChange, "    ")
+			}
+
+			lines = append(lines, line)
+		}
+
+		elements = append(elements, strings.Join(lines, "\n"))
+	}
+
+	for _, group := range release.Groups {
+		lines := []string{group.Title}
+
+		for _, commit := range group.Commits {
+			line := "  " + commit.ShortHash + " "
+
+			if commit.Scope != "" {
+				line += commit.Scope + ": "
+			}
+
+			lines = append(lines, line+commit.Subject)
+		}
+
+		elements = append(elements, strings.Join(lines, "\n"))
+	}
+
+	if len(release.Dependencies) > 0 {
+		lines := []string{"Dependencies"}
+
+		for _, dep := range release.Dependencies {
+			lines = append(lines, fmt.Sprintf("  %s %s -> %s", dep.Name, dep.From, dep.To))
+		}
+
+		elements = append(elements, strings.Join(lines, "\n"))
+	}
+
+	if len(release.GoModules) > 0 {
+		lines := []string{"Go Modules"}
+
+		for _, diff := range release.GoModules {
+			indent := "  "
+
+			if showGoModFile(release.GoModules) {
+				lines = append(lines, "  "+diff.File)
+				indent = "    "
+			}
+
+			for _, line := range diff.lines() {
+				lines = append(lines, indent+line)
+			}
+		}
+
+		elements = append(elements, strings.Join(lines, "\n"))
+	}
+
+	if len(release.DependencyUpdates) > 0 {
+		elements = append(elements, "Dependency updates: "+dependencyUpdatesSummary(release))
+	}
+
+	if release.CompareURL != "" {
+		elements = append(elements, "Full Changelog: "+release.CompareURL)
+	}
+
+	return strings.Join(elements, "\n\n"), nil
+}
+
+// dependencyUpdatesSummary summarizes the collapsed commits with their count and authors,
+// e.g. `3 commits by dependabot[bot], renovate[bot]`.
+func dependencyUpdatesSummary(release Release) string {
+	var (
+		authors []string
+		seen    = map[string]bool{}
+	)
+
+	for _, commit := range release.DependencyUpdates {
+		if !seen[commit.Author] {
+			seen[commit.Author] = true
+
+			authors = append(authors, commit.Author)
+		}
+	}
+
+	noun := "commits"
+
+	if len(release.DependencyUpdates) == 1 {
+		noun = "commit"
+	}
+
+	return fmt.Sprintf("%d %s by %s", len(release.DependencyUpdates), noun, strings.Join(authors, ", "))
+}
+
+// indent prefixes every non empty line of s.
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type templateRenderer struct {
+	tmpl *template.Template
+}
+
+// newTemplateRenderer parses the given text/template source.
+func newTemplateRenderer(text string) (templateRenderer, error) {
+	tmpl, err := template.New("changelog").Funcs(templateFuncs()).Parse(text)
+	if err != nil {
+		return templateRenderer{}, fmt.Errorf("failed to parse template: %s", err)
+	}
+
+	return templateRenderer{tmpl: tmpl}, nil
+}
+
+// Render executes the template against the release.
+func (r templateRenderer) Render(release Release) (string, error) {
+	var buf bytes.Buffer
+
+	if err := r.tmpl.Execute(&buf, release); err != nil {
+		return "", fmt.Errorf("failed to execute template: %s", err)
+	}
+
+	return buf.String(), nil
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trimPrefix": func(prefix, s string) string {
+			return strings.TrimPrefix(s, prefix)
+		},
+		"trimSuffix": func(suffix, s string) string {
+			return strings.TrimSuffix(s, suffix)
+		},
+		"shortHash": func(hash string) string {
+			if len(hash) > 7 {
+				return hash[:7]
+			}
+
+			return hash
+		},
+		"join": func(sep string, elems []string) string {
+			return strings.Join(elems, sep)
+		},
+		"date": func(layout string, t time.Time) string {
+			return t.Format(layout)
+		},
+	}
+}
+
+// loadTemplate returns the template source. Values containing an action delimiter are
+// considered inline templates, otherwise the value is read as a path relative to repoDir.
+func loadTemplate(repoDir, value string) (string, error) {
+	if strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	fp := value
+	if !filepath.IsAbs(fp) {
+		fp = filepath.Join(repoDir, fp)
+	}
+
+	data, err := os.ReadFile(fp) // nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("failed to read template file: %s", err)
+	}
+
+	return string(data), nil
+}
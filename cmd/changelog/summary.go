@@ -0,0 +1,90 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// jobSummary renders the releases, given from oldest to newest, for the job summary of the
+// workflow run, followed by their statistics if enabled.
+func jobSummary(params Params, releases ...Release) (string, error) {
+	r, err := newSummaryRenderer(params)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := renderReleases(r, releases, params.FullHistory)
+	if err != nil {
+		return "", err
+	}
+
+	if params.SummaryStatistics {
+		output = strings.TrimSpace(output) + "\n\n" + statistics(releases)
+	}
+
+	return output, nil
+}
+
+// newSummaryRenderer returns the renderer of the job summary. The summary is markdown
+// unless a template is set.
+func newSummaryRenderer(params Params) (renderer, error) {
+	if params.Template != "" {
+		return newRenderer(params)
+	}
+
+	return markdownRenderer{versionHeading: params.FullHistory}, nil
+}
+
+// statistics renders a markdown table with the number of commits, contributors and breaking
+// changes of the releases and the number of commits per section.
+func statistics(releases []Release) string {
+	var (
+		commits         int
+		breakingChanges int
+		contributors    = map[string]bool{}
+		sections        []string
+		sectionCommits  = map[string]int{}
+	)
+
+	for _, release := range releases {
+		for _, commit := range release.Commits {
+			contributors[commit.Author] = true
+		}
+
+		for _, commit := range release.DependencyUpdates {
+			contributors[commit.Author] = true
+		}
+
+		commits += len(release.Commits) + len(release.DependencyUpdates)
+
+		for _, dep := range release.Dependencies {
+			commits += len(dep.Commits)
+		}
+
+		breakingChanges += len(release.BreakingChanges)
+
+		for _, group := range release.Groups {
+			if _, ok := sectionCommits[group.Title]; !ok {
+				sections = append(sections, group.Title)
+			}
+
+			sectionCommits[group.Title] += len(group.Commits)
+		}
+	}
+
+	lines := []string{
+		"### Statistics",
+		"",
+		"| | Count |",
+		"| --- | ---: |",
+		fmt.Sprintf("| Commits | %d |", commits),
+		fmt.Sprintf("| Contributors | %d |", len(contributors)),
+		fmt.Sprintf("| Breaking changes | %d |", breakingChanges),
+	}
+
+	for _, title := range sections {
+		lines = append(lines, fmt.Sprintf("| %s | %d |", title, sectionCommits[title]))
+	}
+
+	return strings.Join(lines, "\n")
+}
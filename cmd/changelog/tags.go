@@ -0,0 +1,95 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gandarez/changelog-action/pkg/semver"
+)
+
+const (
+	// PreviousTagStrategyDescribe resolves the previous tag as the nearest reachable tag.
+	PreviousTagStrategyDescribe = "describe"
+	// PreviousTagStrategySemver resolves the previous tag as the highest version lower than the current tag.
+	PreviousTagStrategySemver = "semver"
+)
+
+// resolvePreviousTag returns the tag the changelog range starts from according to the strategy.
+// It may result in a commit hash when there is no previous tag. When skipping prereleases, a
+// stable tag rolls up everything since the previous stable tag.
+func resolvePreviousTag(params Params, gc gitClient, tag string) (string, error) {
+	prefix := params.versionPrefix()
+	stableOnly := params.SkipPrereleases && isStableTag(prefix, tag)
+
+	if params.PreviousTagStrategy == PreviousTagStrategySemver {
+		tags, err := gc.Tags()
+		if err != nil {
+			return "", fmt.Errorf("failed to list tags: %s", err)
+		}
+
+		if previous, ok := previousSemverTag(prefix, tag, tags, stableOnly); ok {
+			return previous, nil
+		}
+	}
+
+	previous, err := gc.PreviousTag(tag)
+
+	for stableOnly && err == nil && previous != tag && isPrereleaseTag(prefix, previous) {
+		tag = previous
+		previous, err = gc.PreviousTag(tag)
+	}
+
+	return previous, err
+}
+
+// previousSemverTag returns the tag with the highest semantic version lower than tag.
+// Tags not being a semantic version after removing the prefix are ignored, as well as
+// prereleases when stableOnly is set.
+func previousSemverTag(prefix, tag string, tags []string, stableOnly bool) (string, bool) {
+	current, err := parseTagVersion(prefix, tag)
+	if err != nil {
+		return "", false
+	}
+
+	var (
+		previous string
+		highest  semver.Version
+		found    bool
+	)
+
+	for _, t := range tags {
+		v, err := parseTagVersion(prefix, t)
+		if err != nil || semver.Compare(v, current) >= 0 || (stableOnly && v.IsPrerelease()) {
+			continue
+		}
+
+		if !found || semver.Compare(v, highest) > 0 {
+			previous, highest, found = t, v, true
+		}
+	}
+
+	return previous, found
+}
+
+// parseTagVersion parses the semantic version of a tag having the given prefix.
+func parseTagVersion(prefix, tag string) (semver.Version, error) {
+	if !strings.HasPrefix(tag, prefix) {
+		return semver.Version{}, fmt.Errorf("tag %s does not have prefix %s", tag, prefix)
+	}
+
+	return semver.Parse(strings.TrimPrefix(tag, prefix))
+}
+
+// isStableTag returns true if the tag is a semantic version without prerelease.
+func isStableTag(prefix, tag string) bool {
+	v, err := parseTagVersion(prefix, tag)
+
+	return err == nil && !v.IsPrerelease()
+}
+
+// isPrereleaseTag returns true if the tag is a prerelease semantic version.
+func isPrereleaseTag(prefix, tag string) bool {
+	v, err := parseTagVersion(prefix, tag)
+
+	return err == nil && v.IsPrerelease()
+}
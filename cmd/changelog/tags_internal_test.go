@@ -0,0 +1,75 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviousSemverTag(t *testing.T) {
+	tags := []string{
+		"v1.0.0",
+		"v2.0.0-rc.1",
+		"v1.1.0",
+		"v2.0.0-rc.2",
+		"v2.0.0+build.7",
+		"v1.1.1",
+		"e63c125",
+		"worker/v3.0.0",
+	}
+
+	tests := map[string]struct {
+		Prefix   string
+		Tag      string
+		Stable   bool
+		Expected string
+		Found    bool
+	}{
+		"maintenance release": {
+			Tag:      "v1.1.1",
+			Expected: "v1.1.0",
+			Found:    true,
+		},
+		"stable after prereleases": {
+			Tag:      "v2.0.0+build.7",
+			Expected: "v2.0.0-rc.2",
+			Found:    true,
+		},
+		"stable skipping prereleases": {
+			Tag:      "v2.0.0+build.7",
+			Stable:   true,
+			Expected: "v1.1.1",
+			Found:    true,
+		},
+		"prerelease": {
+			Tag:      "v2.0.0-rc.2",
+			Expected: "v2.0.0-rc.1",
+			Found:    true,
+		},
+		"prerelease skipping prereleases": {
+			Tag:      "v2.0.0-rc.2",
+			Stable:   true,
+			Expected: "v1.1.1",
+			Found:    true,
+		},
+		"first version": {
+			Tag: "v1.0.0",
+		},
+		"not a version": {
+			Tag: "e63c125",
+		},
+		"prefix": {
+			Prefix: "worker/",
+			Tag:    "worker/v3.0.0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			previous, found := previousSemverTag(test.Prefix, test.Tag, tags, test.Stable)
+
+			assert.Equal(t, test.Expected, previous)
+			assert.Equal(t, test.Found, found)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package changelog
+
+import (
+	"github.com/gandarez/changelog-action/pkg/semver"
+)
+
+// nextVersion computes the next semantic version by bumping the previous version according
+// to the commits: breaking changes bump major, features bump minor and anything else bumps
+// patch. When the previous version is not a semantic version, e.g. the root commit hash, the
+// first version is computed from 0.0.0 using the prefix of the current tag. The tag prefix,
+// e.g. `api/` for `api/v1.4.0`, is stripped before parsing and kept in the result.
+func nextVersion(tagPrefix, previousVersion, version string, commits []Commit) string {
+	if len(commits) == 0 {
+		return ""
+	}
+
+	base, err := parseTagVersion(tagPrefix, previousVersion)
+	if err != nil {
+		base = semver.Version{}
+
+		if current, err := parseTagVersion(tagPrefix, version); err == nil {
+			base.Prefix = current.Prefix
+		}
+	}
+
+	return tagPrefix + bump(base, commits).String()
+}
+
+// bump bumps the version according to the most significant commit.
+func bump(base semver.Version, commits []Commit) semver.Version {
+	var hasFeature bool
+
+	for _, commit := range commits {
+		if commit.Breaking {
+			return base.IncMajor()
+		}
+
+		if commit.Type == "feat" {
+			hasFeature = true
+		}
+	}
+
+	if hasFeature {
+		return base.IncMinor()
+	}
+
+	return base.IncPatch()
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/gandarez/changelog-action/cmd/changelog"
 	"github.com/gandarez/changelog-action/pkg/actions"
@@ -13,7 +17,24 @@ import (
 func main() {
 	log.SetHandler(cli.Default)
 
-	result, err := changelog.Run()
+	params, err := changelog.LoadParams(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+
+	if err != nil {
+		log.Errorf("failed to load parameters: %s\n", err)
+
+		os.Exit(1)
+	}
+
+	if params.Command == changelog.CommandLint {
+		lint(params)
+
+		return
+	}
+
+	result, err := changelog.Run(params)
 	if err != nil {
 		log.Errorf("failed to get changelog: %s\n", err)
 
@@ -22,10 +43,88 @@ func main() {
 
 	outputFilepath := os.Getenv("GITHUB_OUTPUT")
 
+	switch {
+	case params.Output != "":
+		if err := os.WriteFile(params.Output, []byte(result.Changelog+"\n"), 0600); err != nil {
+			log.Fatalf("failed to write changelog to %s: %s\n", params.Output, err)
+		}
+	case outputFilepath == "":
+		// Not running inside GitHub Actions.
+		fmt.Println(result.Changelog)
+	}
+
+	if summaryFilepath := os.Getenv("GITHUB_STEP_SUMMARY"); params.Summary && summaryFilepath != "" {
+		if err := actions.AppendSummary(summaryFilepath, result.Summary); err != nil {
+			log.Fatalf("%s\n", err)
+		}
+	}
+
+	if result.NextVersion != "" {
+		log.Infof("NEXT_VERSION: %s", result.NextVersion)
+	}
+
+	if outputFilepath == "" {
+		return
+	}
+
 	// Print changelog.
-	log.Infof("CHANGELOG: %s", result)
+	log.Infof("CHANGELOG: %s", result.Changelog)
+
+	if err := actions.SetOutput(outputFilepath, "CHANGELOG", result.Changelog); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	hasBreakingChanges := strconv.FormatBool(result.HasBreakingChanges)
 
-	if err := actions.SetOutput(outputFilepath, "CHANGELOG", result); err != nil {
+	if err := actions.SetOutput(outputFilepath, "HAS_BREAKING_CHANGES", hasBreakingChanges); err != nil {
 		log.Fatalf("%s\n", err)
 	}
+
+	if result.NextVersion == "" {
+		return
+	}
+
+	if err := actions.SetOutput(outputFilepath, "NEXT_VERSION", result.NextVersion); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+}
+
+// lint checks the commit messages and exits with 1 listing the offending commits. Inside
+// GitHub Actions an error annotation is emitted per problem.
+func lint(params changelog.Params) {
+	violations, err := changelog.RunLint(params)
+	if err != nil {
+		log.Errorf("failed to lint commits: %s\n", err)
+
+		os.Exit(1)
+	}
+
+	if len(violations) == 0 {
+		log.Info("all commits follow the convention")
+
+		return
+	}
+
+	inActions := os.Getenv("GITHUB_ACTIONS") == "true"
+
+	for _, v := range violations {
+		for _, problem := range v.Problems {
+			if !inActions {
+				log.Errorf("%s %q: %s", v.Commit.ShortHash, v.Commit.Header, problem)
+
+				continue
+			}
+
+			err := actions.Error(os.Stdout, fmt.Sprintf("%s: %s", v.Commit.Header, problem), actions.AnnotationProperties{
+				Title: "Commit " + v.Commit.ShortHash,
+			})
+			if err != nil {
+				log.Fatalf("%s\n", err)
+			}
+		}
+	}
+
+	log.Errorf("%d commit(s) do not follow the convention\n", len(violations))
+
+	os.Exit(1)
 }
@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -8,26 +9,130 @@ import (
 	uuid "github.com/nu7hatch/gouuid"
 )
 
-// GetInput gets the input by the given name.
+// GetInput gets the input by the given name, trimmed.
 func GetInput(name string) string {
+	return strings.TrimSpace(GetRawInput(name))
+}
+
+// GetRawInput gets the input by the given name as is, e.g. to keep the line numbers of a
+// multiline input.
+func GetRawInput(name string) string {
 	e := strings.ReplaceAll(name, " ", "_")
 	e = strings.ToUpper(e)
 	e = "INPUT_" + e
 
-	return strings.TrimSpace(os.Getenv(e))
+	return os.Getenv(e)
+}
+
+// Event is the payload of the event that triggered the workflow, limited to the fields in use.
+type Event struct {
+	PullRequest *PullRequest `json:"pull_request"`
+}
+
+// PullRequest is the pull request of a pull_request event.
+type PullRequest struct {
+	Number int            `json:"number"`
+	Base   PullRequestRef `json:"base"`
+	Head   PullRequestRef `json:"head"`
+}
+
+// PullRequestRef is the base or head branch of a pull request.
+type PullRequestRef struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// GetEvent reads the event payload from the given file, usually GITHUB_EVENT_PATH.
+func GetEvent(fp string) (Event, error) {
+	data, err := os.ReadFile(fp) // nolint:gosec
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to read github event file: %s", err)
+	}
+
+	var event Event
+
+	if err := json.Unmarshal(data, &event); err != nil {
+		return Event{}, fmt.Errorf("failed to parse github event: %s", err)
+	}
+
+	return event, nil
+}
+
+// GetPullRequest gets the pull request that triggered the workflow. It returns nil if not
+// running inside GitHub Actions or if the event isn't pull_request or pull_request_target.
+func GetPullRequest() (*PullRequest, error) {
+	switch os.Getenv("GITHUB_EVENT_NAME") {
+	case "pull_request", "pull_request_target":
+	default:
+		return nil, nil
+	}
+
+	fp := os.Getenv("GITHUB_EVENT_PATH")
+	if fp == "" {
+		return nil, nil
+	}
+
+	event, err := GetEvent(fp)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.PullRequest, nil
 }
 
 // SetOutput sets the key value pair to output.
 func SetOutput(fp, key, value string) error {
-	f, err := os.OpenFile(fp, os.O_APPEND|os.O_WRONLY, 0600) // nolint:gosec
-	if err != nil {
-		return fmt.Errorf("failed to open github output file: %s", err)
+	return appendKeyValue(fp, "output", key, value)
+}
+
+// SetEnv sets the environment variable for the next steps of the job and the current process.
+// The given file is usually GITHUB_ENV.
+func SetEnv(fp, name, value string) error {
+	if err := appendKeyValue(fp, "env", name, value); err != nil {
+		return err
 	}
 
-	defer func() {
-		_ = f.Close()
-	}()
+	if err := os.Setenv(name, value); err != nil {
+		return fmt.Errorf("failed to set env %s: %s", name, err)
+	}
+
+	return nil
+}
+
+// AddPath prepends the directory to PATH for the next steps of the job and the current process.
+// The given file is usually GITHUB_PATH.
+func AddPath(fp, dir string) error {
+	if err := appendFile(fp, "path", dir+"\n"); err != nil {
+		return err
+	}
+
+	if err := os.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH")); err != nil {
+		return fmt.Errorf("failed to add %s to path: %s", dir, err)
+	}
+
+	return nil
+}
+
+// SaveState saves the state shared with the pre and post steps of the action. The given file
+// is usually GITHUB_STATE.
+func SaveState(fp, name, value string) error {
+	return appendKeyValue(fp, "state", name, value)
+}
+
+// GetState gets the state saved by the pre or main step of the action.
+func GetState(name string) string {
+	return os.Getenv("STATE_" + name)
+}
+
+// AppendSummary appends the markdown to the job summary of the workflow run. The given file
+// is usually GITHUB_STEP_SUMMARY.
+func AppendSummary(fp, markdown string) error {
+	return appendFile(fp, "summary", strings.TrimRight(markdown, "\n")+"\n")
+}
 
+// appendKeyValue appends the key value pair to a github environment file, using a random
+// delimiter so that the value may span several lines.
+func appendKeyValue(fp, name, key, value string) error {
 	id, err := newId()
 	if err != nil {
 		return err
@@ -35,8 +140,22 @@ func SetOutput(fp, key, value string) error {
 
 	delimiter := fmt.Sprintf("ghadelimiter_%s", id)
 
-	if _, err := f.WriteString(fmt.Sprintf("%s<<%s\n%v\n%s\n", key, delimiter, value, delimiter)); err != nil {
-		return fmt.Errorf("failed to write %s to output: %s", key, err)
+	return appendFile(fp, name, fmt.Sprintf("%s<<%s\n%v\n%s\n", key, delimiter, value, delimiter))
+}
+
+// appendFile appends content to a github environment file, e.g. the output or summary file.
+func appendFile(fp, name, content string) error {
+	f, err := os.OpenFile(fp, os.O_APPEND|os.O_WRONLY, 0600) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open github %s file: %s", name, err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err := f.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write to github %s file: %s", name, err)
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package actions_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gandarez/changelog-action/pkg/actions"
@@ -57,3 +58,151 @@ func TestSetOutput(t *testing.T) {
 		string(data),
 	)
 }
+
+func TestSetEnv(t *testing.T) {
+	envFile, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+
+	defer envFile.Close()
+
+	t.Setenv("CHANGELOG_VERSION", "")
+
+	err = actions.SetEnv(envFile.Name(), "CHANGELOG_VERSION", "v1.2.3")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(envFile.Name())
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^CHANGELOG_VERSION<<ghadelimiter_[0-9a-f-]{36}\nv1\.2\.3\nghadelimiter_[0-9a-f-]{36}\n$`, string(data))
+	assert.Equal(t, "v1.2.3", os.Getenv("CHANGELOG_VERSION"))
+}
+
+func TestAddPath(t *testing.T) {
+	pathFile, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+
+	defer pathFile.Close()
+
+	t.Setenv("PATH", "/usr/bin")
+
+	err = actions.AddPath(pathFile.Name(), "/opt/tools/bin")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(pathFile.Name())
+	require.NoError(t, err)
+
+	assert.Equal(t, "/opt/tools/bin\n", string(data))
+	assert.Equal(t, "/opt/tools/bin"+string(os.PathListSeparator)+"/usr/bin", os.Getenv("PATH"))
+}
+
+func TestSaveState(t *testing.T) {
+	stateFile, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+
+	defer stateFile.Close()
+
+	err = actions.SaveState(stateFile.Name(), "cache_key", "changelog-v1")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(stateFile.Name())
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^cache_key<<ghadelimiter_[0-9a-f-]{36}\nchangelog-v1\nghadelimiter_[0-9a-f-]{36}\n$`, string(data))
+}
+
+func TestGetState(t *testing.T) {
+	t.Setenv("STATE_cache_key", "changelog-v1")
+
+	assert.Equal(t, "changelog-v1", actions.GetState("cache_key"))
+}
+
+func TestAppendSummary(t *testing.T) {
+	summaryFile, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+
+	defer summaryFile.Close()
+
+	err = actions.AppendSummary(summaryFile.Name(), "## Changelog\n")
+	require.NoError(t, err)
+
+	err = actions.AppendSummary(summaryFile.Name(), "### Statistics")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(summaryFile.Name())
+	require.NoError(t, err)
+
+	assert.Equal(t, "## Changelog\n### Statistics\n", string(data))
+}
+
+func TestAppendSummary_Err(t *testing.T) {
+	err := actions.AppendSummary(filepath.Join(t.TempDir(), "missing", "summary"), "## Changelog")
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to open github summary file")
+}
+
+func TestGetEvent(t *testing.T) {
+	event, err := actions.GetEvent("testdata/pull_request.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, actions.Event{
+		PullRequest: &actions.PullRequest{
+			Number: 42,
+			Base: actions.PullRequestRef{
+				Ref: "main",
+				SHA: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			},
+			Head: actions.PullRequestRef{
+				Ref: "feature",
+				SHA: "e5bd3914e2e596debea16f433f57875b5b90bcd6",
+			},
+		},
+	}, event)
+}
+
+func TestGetEvent_Push(t *testing.T) {
+	event, err := actions.GetEvent("testdata/push.json")
+	require.NoError(t, err)
+
+	assert.Nil(t, event.PullRequest)
+}
+
+func TestGetEvent_Err(t *testing.T) {
+	_, err := actions.GetEvent("testdata/missing.json")
+	require.Error(t, err)
+}
+
+func TestGetPullRequest(t *testing.T) {
+	tests := map[string]struct {
+		EventName string
+		EventPath string
+		Expected  *actions.PullRequest
+	}{
+		"pull request": {
+			EventName: "pull_request",
+			EventPath: "testdata/pull_request.json",
+			Expected: &actions.PullRequest{
+				Number: 42,
+				Base:   actions.PullRequestRef{Ref: "main", SHA: "6dcb09b5b57875f334f61aebed695e2e4193db5e"},
+				Head:   actions.PullRequestRef{Ref: "feature", SHA: "e5bd3914e2e596debea16f433f57875b5b90bcd6"},
+			},
+		},
+		"push": {
+			EventName: "push",
+			EventPath: "testdata/push.json",
+		},
+		"not in actions": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GITHUB_EVENT_NAME", test.EventName)
+			t.Setenv("GITHUB_EVENT_PATH", test.EventPath)
+
+			pr, err := actions.GetPullRequest()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, pr)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AnnotationProperties are the optional properties of an annotation.
+type AnnotationProperties struct {
+	Title       string
+	File        string
+	StartLine   int
+	EndLine     int
+	StartColumn int
+	EndColumn   int
+}
+
+// Error writes an error annotation workflow command to w, usually os.Stdout.
+func Error(w io.Writer, message string, props AnnotationProperties) error {
+	return issueCommand(w, "error", props.toMap(), message)
+}
+
+// Warning writes a warning annotation workflow command to w, usually os.Stdout.
+func Warning(w io.Writer, message string, props AnnotationProperties) error {
+	return issueCommand(w, "warning", props.toMap(), message)
+}
+
+// Notice writes a notice annotation workflow command to w, usually os.Stdout.
+func Notice(w io.Writer, message string, props AnnotationProperties) error {
+	return issueCommand(w, "notice", props.toMap(), message)
+}
+
+// AddMask masks the value in the logs of the job.
+func AddMask(w io.Writer, value string) error {
+	return issueCommand(w, "add-mask", nil, value)
+}
+
+// StartGroup starts a collapsible group of log lines, ended by EndGroup.
+func StartGroup(w io.Writer, name string) error {
+	return issueCommand(w, "group", nil, name)
+}
+
+// EndGroup ends the group started by StartGroup.
+func EndGroup(w io.Writer) error {
+	return issueCommand(w, "endgroup", nil, "")
+}
+
+func (p AnnotationProperties) toMap() map[string]string {
+	props := map[string]string{}
+
+	for key, value := range map[string]string{"title": p.Title, "file": p.File} {
+		if value != "" {
+			props[key] = value
+		}
+	}
+
+	for key, value := range map[string]int{
+		"line":      p.StartLine,
+		"endLine":   p.EndLine,
+		"col":       p.StartColumn,
+		"endColumn": p.EndColumn,
+	} {
+		if value > 0 {
+			props[key] = strconv.Itoa(value)
+		}
+	}
+
+	return props
+}
+
+// issueCommand writes a workflow command like `::name key=value,key=value::message` to w.
+func issueCommand(w io.Writer, name string, props map[string]string, message string) error {
+	cmd := "::" + name
+
+	if len(props) > 0 {
+		keys := make([]string, 0, len(props))
+
+		for key := range props {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, len(keys))
+
+		for _, key := range keys {
+			pairs = append(pairs, key+"="+escapeProperty(props[key]))
+		}
+
+		cmd += " " + strings.Join(pairs, ",")
+	}
+
+	if _, err := fmt.Fprintf(w, "%s::%s\n", cmd, escapeData(message)); err != nil {
+		return fmt.Errorf("failed to write %s command: %s", name, err)
+	}
+
+	return nil
+}
+
+func escapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+func escapeProperty(s string) string {
+	s = escapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+
+	return strings.ReplaceAll(s, ",", "%2C")
+}
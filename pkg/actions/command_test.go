@@ -0,0 +1,104 @@
+package actions_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/gandarez/changelog-action/pkg/actions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	tests := map[string]struct {
+		Message  string
+		Props    actions.AnnotationProperties
+		Expected string
+	}{
+		"no properties": {
+			Message:  "something went wrong",
+			Expected: "::error::something went wrong\n",
+		},
+		"properties": {
+			Message: "invalid commit",
+			Props: actions.AnnotationProperties{
+				Title:     "Commit 2b982db",
+				File:      "go.mod",
+				StartLine: 3,
+				EndLine:   4,
+			},
+			Expected: "::error endLine=4,file=go.mod,line=3,title=Commit 2b982db::invalid commit\n",
+		},
+		"escaped": {
+			Message:  "100% wrong\nsecond line",
+			Props:    actions.AnnotationProperties{Title: "lint: a, b"},
+			Expected: "::error title=lint%3A a%2C b::100%25 wrong%0Asecond line\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := actions.Error(&buf, test.Message, test.Props)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, buf.String())
+		})
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		Annotate func(w io.Writer, message string, props actions.AnnotationProperties) error
+		Expected string
+	}{
+		"warning": {
+			Annotate: actions.Warning,
+			Expected: "::warning col=5,file=main.go,line=12,title=Deprecated::use Run instead\n",
+		},
+		"notice": {
+			Annotate: actions.Notice,
+			Expected: "::notice col=5,file=main.go,line=12,title=Deprecated::use Run instead\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := test.Annotate(&buf, "use Run instead", actions.AnnotationProperties{
+				Title:       "Deprecated",
+				File:        "main.go",
+				StartLine:   12,
+				StartColumn: 5,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, buf.String())
+		})
+	}
+}
+
+func TestAddMask(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := actions.AddMask(&buf, "s3cr3t")
+	require.NoError(t, err)
+
+	assert.Equal(t, "::add-mask::s3cr3t\n", buf.String())
+}
+
+func TestGroup(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := actions.StartGroup(&buf, "Changelog")
+	require.NoError(t, err)
+
+	err = actions.EndGroup(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, "::group::Changelog\n::endgroup::\n", buf.String())
+}
@@ -0,0 +1,174 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Inputs gets typed action inputs. Values are read with a lookup function so that, e.g.,
+// command line flags can take precedence over the action inputs.
+type Inputs struct {
+	lookup func(name string) string
+}
+
+// NewInputs returns Inputs reading the values with lookup, or GetRawInput if nil. The values
+// are expected as is, so that multiline inputs keep their line numbers.
+func NewInputs(lookup func(name string) string) Inputs {
+	if lookup == nil {
+		lookup = GetRawInput
+	}
+
+	return Inputs{lookup: lookup}
+}
+
+// GetInput gets the input by the given name, trimmed.
+func (in Inputs) GetInput(name string) string {
+	return strings.TrimSpace(in.lookup(name))
+}
+
+// GetBooleanInput gets the boolean input by the given name. It returns def if not set.
+func (in Inputs) GetBooleanInput(name string, def bool) (bool, error) {
+	value := in.GetInput(name)
+	if value == "" {
+		return def, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s argument: %s, expected true or false", name, value)
+	}
+
+	return parsed, nil
+}
+
+// GetIntInput gets the integer input by the given name. It returns def if not set.
+func (in Inputs) GetIntInput(name string, def int) (int, error) {
+	value := in.GetInput(name)
+	if value == "" {
+		return def, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s argument: %s, expected an integer", name, value)
+	}
+
+	return parsed, nil
+}
+
+// GetEnumInput gets the input by the given name, which must be one of allowed. It returns
+// def if not set.
+func (in Inputs) GetEnumInput(name, def string, allowed ...string) (string, error) {
+	value := in.GetInput(name)
+	if value == "" {
+		return def, nil
+	}
+
+	for _, a := range allowed {
+		if value == a {
+			return value, nil
+		}
+	}
+
+	return def, fmt.Errorf("invalid %s argument: %s, expected one of: %s", name, value, strings.Join(allowed, ", "))
+}
+
+// GetMultilineInput gets the input by the given name as a list of lines. Lines are trimmed,
+// and blank lines and comments, i.e. lines starting with `# `, are skipped.
+func (in Inputs) GetMultilineInput(name string) []string {
+	var values []string
+
+	for _, l := range multiline(in.lookup(name)) {
+		values = append(values, l.value)
+	}
+
+	return values
+}
+
+// GetRegexpInput gets the multiline input by the given name, checking every line is a valid
+// regular expression. Invalid lines are reported with their line number.
+func (in Inputs) GetRegexpInput(name string) ([]string, error) {
+	var (
+		values []string
+		errs   []error
+	)
+
+	for _, l := range multiline(in.lookup(name)) {
+		if _, err := regexp.Compile(l.value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s argument: line %d: %s", name, l.number, err))
+
+			continue
+		}
+
+		values = append(values, l.value)
+	}
+
+	return values, errors.Join(errs...)
+}
+
+// CheckRequired returns an error listing every input not set.
+func (in Inputs) CheckRequired(names ...string) error {
+	var errs []error
+
+	for _, name := range names {
+		if in.GetInput(name) == "" {
+			errs = append(errs, fmt.Errorf("missing required %s argument", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GetBooleanInput gets the boolean action input by the given name. It returns def if not set.
+func GetBooleanInput(name string, def bool) (bool, error) {
+	return NewInputs(nil).GetBooleanInput(name, def)
+}
+
+// GetIntInput gets the integer action input by the given name. It returns def if not set.
+func GetIntInput(name string, def int) (int, error) {
+	return NewInputs(nil).GetIntInput(name, def)
+}
+
+// GetEnumInput gets the action input by the given name, which must be one of allowed. It
+// returns def if not set.
+func GetEnumInput(name, def string, allowed ...string) (string, error) {
+	return NewInputs(nil).GetEnumInput(name, def, allowed...)
+}
+
+// GetMultilineInput gets the action input by the given name as a list of lines, skipping
+// blank lines and comments.
+func GetMultilineInput(name string) []string {
+	return NewInputs(nil).GetMultilineInput(name)
+}
+
+// CheckRequiredInputs returns an error listing every action input not set.
+func CheckRequiredInputs(names ...string) error {
+	return NewInputs(nil).CheckRequired(names...)
+}
+
+// line is a line of a multiline input with its 1-based number.
+type line struct {
+	number int
+	value  string
+}
+
+// multiline splits a multiline input into trimmed lines, skipping blank lines and comments.
+// A comment starts with `# ` so that a regular expression like `#\d+` is kept.
+func multiline(value string) []line {
+	var lines []line
+
+	for i, v := range strings.Split(value, "\n") {
+		v = strings.TrimSpace(v)
+
+		if v == "" || v == "#" || strings.HasPrefix(v, "# ") {
+			continue
+		}
+
+		lines = append(lines, line{number: i + 1, value: v})
+	}
+
+	return lines
+}
@@ -0,0 +1,144 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/changelog-action/pkg/actions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBooleanInput(t *testing.T) {
+	tests := map[string]struct {
+		Value    string
+		Expected bool
+	}{
+		"not set": {
+			Expected: true,
+		},
+		"true": {
+			Value:    "true",
+			Expected: true,
+		},
+		"false": {
+			Value:    " FALSE ",
+			Expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("INPUT_SUMMARY", test.Value)
+
+			value, err := actions.GetBooleanInput("summary", true)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, value)
+		})
+	}
+}
+
+func TestGetBooleanInput_Err(t *testing.T) {
+	t.Setenv("INPUT_SUMMARY", "yes")
+
+	_, err := actions.GetBooleanInput("summary", false)
+
+	assert.EqualError(t, err, "invalid summary argument: yes, expected true or false")
+}
+
+func TestGetIntInput(t *testing.T) {
+	t.Setenv("INPUT_DEPTH", "42")
+
+	value, err := actions.GetIntInput("depth", 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, value)
+
+	value, err = actions.GetIntInput("missing", 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 10, value)
+}
+
+func TestGetIntInput_Err(t *testing.T) {
+	t.Setenv("INPUT_DEPTH", "4.2")
+
+	_, err := actions.GetIntInput("depth", 10)
+
+	assert.EqualError(t, err, "invalid depth argument: 4.2, expected an integer")
+}
+
+func TestGetEnumInput(t *testing.T) {
+	t.Setenv("INPUT_FORMAT", "json")
+
+	value, err := actions.GetEnumInput("format", "markdown", "markdown", "json")
+	require.NoError(t, err)
+
+	assert.Equal(t, "json", value)
+
+	value, err = actions.GetEnumInput("missing", "markdown", "markdown", "json")
+	require.NoError(t, err)
+
+	assert.Equal(t, "markdown", value)
+}
+
+func TestGetEnumInput_Err(t *testing.T) {
+	t.Setenv("INPUT_FORMAT", "yaml")
+
+	_, err := actions.GetEnumInput("format", "markdown", "markdown", "json")
+
+	assert.EqualError(t, err, "invalid format argument: yaml, expected one of: markdown, json")
+}
+
+func TestGetMultilineInput(t *testing.T) {
+	t.Setenv("INPUT_PATHS", "\n  services/api  \n# documentation\n\nlibs/common\n#\n")
+
+	assert.Equal(t, []string{"services/api", "libs/common"}, actions.GetMultilineInput("paths"))
+}
+
+func TestInputs_GetRegexpInput(t *testing.T) {
+	in := actions.NewInputs(func(string) string {
+		return "# issues\n#\\d+\n\n[a-"
+	})
+
+	values, err := in.GetRegexpInput("exclude")
+
+	assert.Equal(t, []string{`#\d+`}, values)
+	assert.EqualError(t, err, "invalid exclude argument: line 4: error parsing regexp: missing closing ]: `[a-`")
+}
+
+func TestInputs_GetRegexpInputLeadingBlankLines(t *testing.T) {
+	in := actions.NewInputs(func(string) string {
+		return "\n\n^ok\n(bad"
+	})
+
+	values, err := in.GetRegexpInput("exclude")
+
+	assert.Equal(t, []string{"^ok"}, values)
+	assert.EqualError(t, err, "invalid exclude argument: line 4: error parsing regexp: missing closing ): `(bad`")
+}
+
+func TestInputs_Lookup(t *testing.T) {
+	t.Setenv("INPUT_FORMAT", "json")
+
+	in := actions.NewInputs(func(name string) string {
+		if name == "format" {
+			return "text"
+		}
+
+		return actions.GetInput(name)
+	})
+
+	assert.Equal(t, "text", in.GetInput("format"))
+}
+
+func TestCheckRequiredInputs(t *testing.T) {
+	t.Setenv("INPUT_TOKEN", "s3cr3t")
+
+	require.NoError(t, actions.CheckRequiredInputs("token"))
+
+	err := actions.CheckRequiredInputs("token", "repository", "ref")
+
+	assert.EqualError(t, err, "missing required repository argument\nmissing required ref argument")
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// fieldSeparator is the ASCII unit separator placed between commit fields.
+	fieldSeparator = "\x1f"
+	// recordSeparator is the ASCII record separator placed after each commit.
+	recordSeparator = "\x1e"
+	// logFormat is the git log format of a commit: full hash, short hash, author name, author email,
+	// committer date, subject, body and parents.
+	logFormat = "%H%x1f%h%x1f%an%x1f%ae%x1f%cI%x1f%s%x1f%b%x1f%P%x1e"
+	// logFields is the number of fields in logFormat.
+	logFields = 8
+)
+
+// Commit is a commit as listed by git log.
+type Commit struct {
+	Hash        string
+	ShortHash   string
+	AuthorName  string
+	AuthorEmail string
+	Date        time.Time
+	Subject     string
+	Body        string
+	Parents     []string
+}
+
+// parseLog parses the output of git log formatted with logFormat.
+func parseLog(out string) ([]Commit, error) {
+	var commits []Commit
+
+	for _, record := range strings.Split(out, recordSeparator) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.Split(record, fieldSeparator)
+		if len(fields) != logFields {
+			return nil, fmt.Errorf("failed to parse commit: expected %d fields, got %d", logFields, len(fields))
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date of commit %s: %s", fields[0], err)
+		}
+
+		commits = append(commits, Commit{
+			Hash:        fields[0],
+			ShortHash:   fields[1],
+			AuthorName:  fields[2],
+			AuthorEmail: fields[3],
+			Date:        date,
+			Subject:     fields[5],
+			Body:        strings.TrimSpace(fields[6]),
+			Parents:     strings.Fields(fields[7]),
+		})
+	}
+
+	return commits, nil
+}
@@ -15,18 +15,22 @@ import (
 type Client struct {
 	repoDir string
 	GitCmd  func(env map[string]string, args ...string) (string, error)
+	// TagPattern is a glob, e.g. `api/v*`, restricting tag discovery to matching tags.
+	TagPattern string
 }
 
 // NewGit creates a new git instance.
 func NewGit(repoDir string) *Client {
 	return &Client{
 		repoDir: repoDir,
-		GitCmd:  gitCmdFn,
+		GitCmd: func(env map[string]string, args ...string) (string, error) {
+			return gitCmdFn(repoDir, env, args...)
+		},
 	}
 }
 
-// gitCmdFn runs a git command with the specified env vars and returns its output or errors.
-func gitCmdFn(env map[string]string, args ...string) (string, error) {
+// gitCmdFn runs a git command inside dir with the specified env vars and returns its output or errors.
+func gitCmdFn(dir string, env map[string]string, args ...string) (string, error) {
 	var extraArgs = []string{
 		"-c", "log.showSignature=false",
 	}
@@ -36,6 +40,8 @@ func gitCmdFn(env map[string]string, args ...string) (string, error) {
 	/* #nosec */
 	var cmd = exec.Command("git", args...)
 
+	cmd.Dir = dir
+
 	if env != nil {
 		cmd.Env = []string{}
 		for k, v := range env {
@@ -105,10 +111,10 @@ func (c *Client) IsRepo() bool {
 func (c *Client) LatestTagOrHash() string {
 	for _, fn := range []func() (string, error){
 		func() (string, error) {
-			return c.Clean(c.Run("tag", "--points-at", "HEAD", "--sort", "-version:creatordate"))
+			return c.Clean(c.Run(c.withTagList("tag", "--points-at", "HEAD", "--sort", "-version:creatordate")...))
 		},
 		func() (string, error) {
-			return c.Clean(c.Run("describe", "--tags", "--abbrev=0"))
+			return c.Clean(c.Run(c.withDescribeMatch("describe", "--tags", "--abbrev=0")...))
 		},
 		func() (string, error) {
 			return c.Clean(c.Run("rev-parse", "HEAD"))
@@ -130,7 +136,10 @@ func (c *Client) PreviousTag(tag string) (string, error) {
 		err    error
 	)
 
-	result, err = c.Clean(c.Run("describe", "--tags", "--abbrev=0", fmt.Sprintf("tags/%s^", tag)))
+	args := c.withDescribeMatch("describe", "--tags", "--abbrev=0")
+	args = append(args, fmt.Sprintf("tags/%s^", tag))
+
+	result, err = c.Clean(c.Run(args...))
 	if err != nil {
 		result, err = c.Clean(c.Run("rev-list", "--max-parents=0", "HEAD"))
 	}
@@ -138,6 +147,39 @@ func (c *Client) PreviousTag(tag string) (string, error) {
 	return result, err
 }
 
+// withTagList restricts a `git tag` listing to the tag pattern when set.
+func (c *Client) withTagList(args ...string) []string {
+	if c.TagPattern == "" {
+		return args
+	}
+
+	return append(args, "--list", c.TagPattern)
+}
+
+// withDescribeMatch restricts `git describe` to the tag pattern when set.
+func (c *Client) withDescribeMatch(args ...string) []string {
+	if c.TagPattern == "" {
+		return args
+	}
+
+	return append(args, "--match", c.TagPattern)
+}
+
+// IsHead returns true if ref points at the HEAD commit.
+func (c *Client) IsHead(ref string) (bool, error) {
+	out, err := c.Run("rev-parse", "HEAD", ref+"^{commit}")
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve %s: %s", ref, strings.Split(err.Error(), "\n")[0])
+	}
+
+	commits := strings.Fields(out)
+	if len(commits) != 2 {
+		return false, fmt.Errorf("failed to resolve %s: unexpected output %q", ref, out)
+	}
+
+	return commits[0] == commits[1], nil
+}
+
 // TagExists returns true if the tag exists.
 func (c *Client) TagExists(tag string) bool {
 	result, err := c.Run("tag", "-l", tag)
@@ -148,9 +190,41 @@ func (c *Client) TagExists(tag string) bool {
 	return strings.TrimSpace(result) == tag
 }
 
-func (c *Client) Log(refs ...string) (string, error) {
-	var args = []string{"log", "--pretty=oneline", "--abbrev-commit", "--no-decorate", "--no-color"}
+// Tags returns the tags matching the tag pattern sorted from oldest to newest. Tags created
+// at the same time, e.g. lightweight tags of the same commit, are sorted by version with
+// prereleases before their release.
+func (c *Client) Tags() ([]string, error) {
+	out, err := c.Run(c.withTagList("-c", "versionsort.suffix=-", "tag", "--sort=version:refname", "--sort=creatordate")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+
+	for _, line := range strings.Split(out, "\n") {
+		if tag := strings.TrimSpace(line); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// Log returns the commits of the given refs. When paths are given only commits touching them
+// are listed. Paths are passed as git pathspecs, so exclusions like `:(exclude)docs` are supported.
+func (c *Client) Log(refs []string, paths ...string) ([]Commit, error) {
+	var args = []string{"log", "--pretty=tformat:" + logFormat, "--no-color"}
 	args = append(args, refs...)
 
-	return c.Run(args...)
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
+
+	out, err := c.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLog(out)
 }
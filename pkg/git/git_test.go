@@ -2,7 +2,9 @@ package git_test
 
 import (
 	"errors"
+	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/gandarez/changelog-action/pkg/git"
 
@@ -71,6 +73,34 @@ func TestLatestTag_NoTagFound(t *testing.T) {
 	assert.Empty(t, value)
 }
 
+func TestLatestTag_TagPattern(t *testing.T) {
+	var numCalls int
+
+	gc := git.NewGit("/path/to/repo")
+	gc.TagPattern = "api/v*"
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		numCalls++
+
+		assert.Nil(t, env)
+
+		switch numCalls {
+		case 1:
+			assert.Equal(t, args, []string{
+				"tag", "--points-at", "HEAD", "--sort", "-version:creatordate", "--list", "api/v*"})
+
+			return "", nil
+		case 2:
+			assert.Equal(t, args, []string{"describe", "--tags", "--abbrev=0", "--match", "api/v*"})
+		}
+
+		return "api/v1.4.0", nil
+	}
+
+	value := gc.LatestTagOrHash()
+
+	assert.Equal(t, "api/v1.4.0", value)
+}
+
 func TestPreviousTag(t *testing.T) {
 	gc := git.NewGit("/path/to/repo")
 	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
@@ -86,6 +116,22 @@ func TestPreviousTag(t *testing.T) {
 	assert.Equal(t, "v1.4.8", value)
 }
 
+func TestPreviousTag_TagPattern(t *testing.T) {
+	gc := git.NewGit("/path/to/repo")
+	gc.TagPattern = "api/v*"
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"describe", "--tags", "--abbrev=0", "--match", "api/v*", "tags/api/v1.4.0^"})
+
+		return "api/v1.3.2", nil
+	}
+
+	value, err := gc.PreviousTag("api/v1.4.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "api/v1.3.2", value)
+}
+
 func TestPreviousTagErr(t *testing.T) {
 	var numCalls int
 
@@ -110,21 +156,161 @@ func TestPreviousTagErr(t *testing.T) {
 	assert.EqualError(t, err, "error")
 }
 
+func TestIsHead(t *testing.T) {
+	tests := map[string]struct {
+		Output   string
+		Expected bool
+	}{
+		"head": {
+			Output:   "2b982db6c4e4a1b9e3f0c7b2a8d1e5f4a3b2c1d0\n2b982db6c4e4a1b9e3f0c7b2a8d1e5f4a3b2c1d0\n",
+			Expected: true,
+		},
+		"behind head": {
+			Output:   "2b982db6c4e4a1b9e3f0c7b2a8d1e5f4a3b2c1d0\n5a359bb8e1f0a2b3c4d5e6f708192a3b4c5d6e7f\n",
+			Expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := git.NewGit("/path/to/repo")
+			gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+				assert.Nil(t, env)
+				assert.Equal(t, args, []string{"rev-parse", "HEAD", "v1.1.0^{commit}"})
+
+				return test.Output, nil
+			}
+
+			isHead, err := gc.IsHead("v1.1.0")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, isHead)
+		})
+	}
+}
+
+func TestIsHeadErr(t *testing.T) {
+	gc := git.NewGit("/path/to/repo")
+	gc.GitCmd = func(_ map[string]string, _ ...string) (string, error) {
+		return "", errors.New("fatal: ambiguous argument 'v1.2.0^{commit}': unknown revision or path not in the working tree.\n" +
+			"Use '--' to separate paths from revisions\n")
+	}
+
+	_, err := gc.IsHead("v1.2.0")
+
+	assert.EqualError(t, err, "failed to resolve v1.2.0: fatal: ambiguous argument 'v1.2.0^{commit}': "+
+		"unknown revision or path not in the working tree.")
+}
+
+func TestTags(t *testing.T) {
+	gc := git.NewGit("/path/to/repo")
+	gc.TagPattern = "api/v*"
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{
+			"-c", "versionsort.suffix=-", "tag", "--sort=version:refname", "--sort=creatordate", "--list", "api/v*"})
+
+		return "api/v1.0.0\napi/v1.1.0\napi/v1.0.1\n", nil
+	}
+
+	tags, err := gc.Tags()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"api/v1.0.0", "api/v1.1.0", "api/v1.0.1"}, tags)
+}
+
+func TestTags_SameCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	gc := git.NewGit(t.TempDir())
+	gc.TagPattern = "v*"
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"-c", "user.name=John Doe", "-c", "user.email=john@example.com", "commit", "--allow-empty", "-m", "first"},
+		{"tag", "v1.9.0"},
+		{"-c", "user.name=John Doe", "-c", "user.email=john@example.com", "commit", "--allow-empty", "-m", "second"},
+		{"tag", "v2.0.0"},
+		{"tag", "v2.0.0-rc.2"},
+		{"tag", "v2.0.0-rc.10"},
+	} {
+		_, err := gc.Run(args...)
+		require.NoError(t, err)
+	}
+
+	tags, err := gc.Tags()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"v1.9.0", "v2.0.0-rc.2", "v2.0.0-rc.10", "v2.0.0"}, tags)
+}
+
+func TestTagsErr(t *testing.T) {
+	gc := git.NewGit("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-c", "versionsort.suffix=-", "tag", "--sort=version:refname", "--sort=creatordate"})
+
+		return "", errors.New("error")
+	}
+
+	_, err := gc.Tags()
+
+	assert.EqualError(t, err, "error")
+}
+
 func TestLog(t *testing.T) {
 	gc := git.NewGit("/path/to/repo")
 	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
 		assert.Nil(t, env)
 		assert.Equal(t, args, []string{
-			"log", "--pretty=oneline", "--abbrev-commit",
-			"--no-decorate", "--no-color", "v1.2.3..v1.3.0"})
+			"log", "--pretty=tformat:%H%x1f%h%x1f%an%x1f%ae%x1f%cI%x1f%s%x1f%b%x1f%P%x1e",
+			"--no-color", "v1.2.3..v1.3.0"})
+
+		return "2b982db6c4e4a1b9e3f0c7b2a8d1e5f4a3b2c1d0\x1f2b982db\x1fJohn Doe\x1fjohn@example.com\x1f" +
+			"2024-03-01T10:20:30+01:00\x1ffeat: add workflows\x1fRun tests on push.\n\n" +
+			"Refs: #12\n\x1f5a359bb8e1f0a2b3c4d5e6f708192a3b4c5d6e7f\x1e\n" +
+			"5a359bb8e1f0a2b3c4d5e6f708192a3b4c5d6e7f\x1f5a359bb\x1fJane Doe\x1fjane@example.com\x1f" +
+			"2024-02-28T09:00:00Z\x1fFix logging\x1f\x1f\x1e\n", nil
+	}
+
+	value, err := gc.Log([]string{"v1.2.3..v1.3.0"})
+	require.NoError(t, err)
 
-		return "2b982db Add workflows\n5a359bb Fix logging", nil
+	assert.Equal(t, []git.Commit{
+		{
+			Hash:        "2b982db6c4e4a1b9e3f0c7b2a8d1e5f4a3b2c1d0",
+			ShortHash:   "2b982db",
+			AuthorName:  "John Doe",
+			AuthorEmail: "john@example.com",
+			Date:        time.Date(2024, 3, 1, 10, 20, 30, 0, time.FixedZone("", 3600)),
+			Subject:     "feat: add workflows",
+			Body:        "Run tests on push.\n\nRefs: #12",
+			Parents:     []string{"5a359bb8e1f0a2b3c4d5e6f708192a3b4c5d6e7f"},
+		},
+		{
+			Hash:        "5a359bb8e1f0a2b3c4d5e6f708192a3b4c5d6e7f",
+			ShortHash:   "5a359bb",
+			AuthorName:  "Jane Doe",
+			AuthorEmail: "jane@example.com",
+			Date:        time.Date(2024, 2, 28, 9, 0, 0, 0, time.UTC),
+			Subject:     "Fix logging",
+			Parents:     []string{},
+		},
+	}, value)
+}
+
+func TestLog_Empty(t *testing.T) {
+	gc := git.NewGit("/path/to/repo")
+	gc.GitCmd = func(_ map[string]string, _ ...string) (string, error) {
+		return "", nil
 	}
 
-	value, err := gc.Log("v1.2.3..v1.3.0")
+	value, err := gc.Log([]string{"v1.2.3..v1.3.0"})
 	require.NoError(t, err)
 
-	assert.Equal(t, "2b982db Add workflows\n5a359bb Fix logging", value)
+	assert.Empty(t, value)
 }
 
 func TestLogErr(t *testing.T) {
@@ -132,13 +318,39 @@ func TestLogErr(t *testing.T) {
 	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
 		assert.Nil(t, env)
 		assert.Equal(t, args, []string{
-			"log", "--pretty=oneline", "--abbrev-commit",
-			"--no-decorate", "--no-color", "v1.2.3..v1.3.0"})
+			"log", "--pretty=tformat:%H%x1f%h%x1f%an%x1f%ae%x1f%cI%x1f%s%x1f%b%x1f%P%x1e",
+			"--no-color", "v1.2.3..v1.3.0"})
 
 		return "", errors.New("error")
 	}
 
-	_, err := gc.Log("v1.2.3..v1.3.0")
+	_, err := gc.Log([]string{"v1.2.3..v1.3.0"})
 
 	assert.EqualError(t, err, "error")
 }
+
+func TestLog_ParseErr(t *testing.T) {
+	gc := git.NewGit("/path/to/repo")
+	gc.GitCmd = func(_ map[string]string, _ ...string) (string, error) {
+		return "2b982db Add workflows\n", nil
+	}
+
+	_, err := gc.Log([]string{"v1.2.3..v1.3.0"})
+
+	assert.EqualError(t, err, "failed to parse commit: expected 8 fields, got 1")
+}
+
+func TestLog_Paths(t *testing.T) {
+	gc := git.NewGit("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{
+			"log", "--pretty=tformat:%H%x1f%h%x1f%an%x1f%ae%x1f%cI%x1f%s%x1f%b%x1f%P%x1e",
+			"--no-color", "v1.2.3..v1.3.0", "--", "services/api", ":(exclude)services/api/docs"})
+
+		return "", nil
+	}
+
+	_, err := gc.Log([]string{"v1.2.3..v1.3.0"}, "services/api", ":(exclude)services/api/docs")
+	require.NoError(t, err)
+}
@@ -0,0 +1,148 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Provider is a git hosting service.
+type Provider string
+
+const (
+	// ProviderGitHub is github.com or GitHub Enterprise.
+	ProviderGitHub Provider = "github"
+	// ProviderGitLab is gitlab.com or a self-hosted GitLab.
+	ProviderGitLab Provider = "gitlab"
+	// ProviderBitbucket is bitbucket.org.
+	ProviderBitbucket Provider = "bitbucket"
+	// ProviderGitea is a Gitea or Forgejo instance, e.g. codeberg.org.
+	ProviderGitea Provider = "gitea"
+)
+
+// scpLikeRegex matches the scp-like SSH syntax, e.g. `git@github.com:owner/repo.git`.
+var scpLikeRegex = regexp.MustCompile(`^(?:[\w.-]+@)?([\w.-]+):([^/].*)$`)
+
+// Remote is the web location of a repository.
+type Remote struct {
+	Provider Provider
+	// BaseURL is the repository web URL, e.g. `https://github.com/owner/repo`.
+	BaseURL string
+}
+
+// ParseRemote parses a remote URL in SSH, scp-like or HTTP(S) form. The provider is detected
+// from the host name and defaults to GitHub, whose links are also understood by Gitea.
+func ParseRemote(remote string) (Remote, error) {
+	remote = strings.TrimSpace(remote)
+
+	var host, path string
+
+	switch match := scpLikeRegex.FindStringSubmatch(remote); {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Remote{}, fmt.Errorf("invalid remote url: %s", remote)
+		}
+
+		host, path = u.Hostname(), u.Path
+	case match != nil:
+		host, path = match[1], match[2]
+	default:
+		return Remote{}, fmt.Errorf("invalid remote url: %s", remote)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	if host == "" || !strings.Contains(path, "/") {
+		return Remote{}, fmt.Errorf("invalid remote url: %s", remote)
+	}
+
+	return Remote{
+		Provider: detectProvider(host),
+		BaseURL:  "https://" + host + "/" + path,
+	}, nil
+}
+
+// ParseRepoURL parses a repository web URL set by the user, e.g. for self-hosted instances
+// on custom domains, keeping its scheme, port and path as is.
+func ParseRepoURL(repoURL string) (Remote, error) {
+	u, err := url.Parse(strings.TrimSpace(repoURL))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return Remote{}, fmt.Errorf("invalid repo url: %s", repoURL)
+	}
+
+	u.User = nil
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), ".git")
+
+	return Remote{
+		Provider: detectProvider(u.Hostname()),
+		BaseURL:  u.String(),
+	}, nil
+}
+
+func detectProvider(host string) Provider {
+	host = strings.ToLower(host)
+
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return ProviderGitLab
+	case strings.Contains(host, "bitbucket"):
+		return ProviderBitbucket
+	case strings.Contains(host, "gitea"), strings.Contains(host, "codeberg"), strings.Contains(host, "forgejo"):
+		return ProviderGitea
+	default:
+		return ProviderGitHub
+	}
+}
+
+// CommitURL returns the web URL of a commit.
+func (r Remote) CommitURL(hash string) string {
+	switch r.Provider {
+	case ProviderGitLab:
+		return r.BaseURL + "/-/commit/" + hash
+	case ProviderBitbucket:
+		return r.BaseURL + "/commits/" + hash
+	default:
+		return r.BaseURL + "/commit/" + hash
+	}
+}
+
+// CompareURL returns the web URL comparing from with to.
+func (r Remote) CompareURL(from, to string) string {
+	switch r.Provider {
+	case ProviderGitLab:
+		return r.BaseURL + "/-/compare/" + from + "..." + to
+	case ProviderBitbucket:
+		return r.BaseURL + "/branches/compare/" + to + "%0D" + from
+	default:
+		return r.BaseURL + "/compare/" + from + "..." + to
+	}
+}
+
+// IssueURL returns the web URL of an issue. Pull requests are reached through their issue
+// number on GitHub and Gitea.
+func (r Remote) IssueURL(number string) string {
+	if r.Provider == ProviderGitLab {
+		return r.BaseURL + "/-/issues/" + number
+	}
+
+	return r.BaseURL + "/issues/" + number
+}
+
+// WithRepo returns the remote of another repository, given as `owner/repo`, on the same host.
+func (r Remote) WithRepo(repo string) Remote {
+	u, err := url.Parse(r.BaseURL)
+	if err != nil {
+		return r
+	}
+
+	u.Path, u.RawPath = "", ""
+
+	return Remote{Provider: r.Provider, BaseURL: u.String() + "/" + repo}
+}
+
+// RemoteURL returns the url of the origin remote.
+func (c *Client) RemoteURL() (string, error) {
+	return c.Clean(c.Run("config", "--get", "remote.origin.url"))
+}
@@ -0,0 +1,169 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionRegex matches a semantic version with an optional leading "v".
+var versionRegex = regexp.MustCompile(
+	`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// Version is a semantic version as defined by https://semver.org.
+type Version struct {
+	Prefix     string
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// Parse parses a semantic version. A leading "v" is accepted and kept as prefix.
+func Parse(s string) (Version, error) {
+	match := versionRegex.FindStringSubmatch(s)
+	if match == nil {
+		return Version{}, fmt.Errorf("invalid semantic version: %s", s)
+	}
+
+	var nums [3]uint64
+
+	for i, str := range match[2:5] {
+		n, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid semantic version: %s", s)
+		}
+
+		nums[i] = n
+	}
+
+	return Version{
+		Prefix:     match[1],
+		Major:      nums[0],
+		Minor:      nums[1],
+		Patch:      nums[2],
+		Prerelease: match[5],
+		Build:      match[6],
+	}, nil
+}
+
+// String returns the version including prefix, prerelease and build metadata.
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// IsPrerelease returns true if the version has prerelease identifiers.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// IncMajor returns the next major version. A prerelease of a major version is released as is.
+func (v Version) IncMajor() Version {
+	if !v.IsPrerelease() || v.Minor != 0 || v.Patch != 0 {
+		v.Major++
+	}
+
+	return Version{Prefix: v.Prefix, Major: v.Major}
+}
+
+// IncMinor returns the next minor version. A prerelease of a minor version is released as is.
+func (v Version) IncMinor() Version {
+	if !v.IsPrerelease() || v.Patch != 0 {
+		v.Minor++
+	}
+
+	return Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor}
+}
+
+// IncPatch returns the next patch version. A prerelease is released as is.
+func (v Version) IncPatch() Version {
+	if !v.IsPrerelease() {
+		v.Patch++
+	}
+
+	return Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// Compare returns -1, 0 or 1 if a has lower, equal or higher precedence than b.
+// Prefix and build metadata are ignored.
+func Compare(a, b Version) int {
+	for _, pair := range [][2]uint64{{a.Major, b.Major}, {a.Minor, b.Minor}, {a.Patch, b.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+// comparePrerelease compares prerelease identifiers. A version without prerelease has
+// higher precedence, numeric identifiers have lower precedence than alphanumeric ones
+// and a larger set of identifiers has higher precedence when all preceding are equal.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		default:
+			return 0
+		}
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
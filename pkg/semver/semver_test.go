@@ -0,0 +1,133 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/changelog-action/pkg/semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		Value    string
+		Expected semver.Version
+	}{
+		"simple": {
+			Value:    "1.2.3",
+			Expected: semver.Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		"prefix": {
+			Value:    "v0.10.0",
+			Expected: semver.Version{Prefix: "v", Minor: 10},
+		},
+		"prerelease and build": {
+			Value: "v2.0.0-rc.1+build.5",
+			Expected: semver.Version{
+				Prefix:     "v",
+				Major:      2,
+				Prerelease: "rc.1",
+				Build:      "build.5",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := semver.Parse(test.Value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, v)
+			assert.Equal(t, test.Value, v.String())
+		})
+	}
+}
+
+func TestParseErr(t *testing.T) {
+	for _, value := range []string{"", "1.2", "v01.2.3", "1.2.3-", "e63c125b28842b17546cc92f635d7eccc8e909a7"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := semver.Parse(value)
+
+			assert.EqualError(t, err, "invalid semantic version: "+value)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// ordered from lowest to highest precedence as listed in the specification
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"v1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i < len(versions)-1; i++ {
+		a, err := semver.Parse(versions[i])
+		require.NoError(t, err)
+
+		b, err := semver.Parse(versions[i+1])
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, semver.Compare(a, b), "%s < %s", a, b)
+		assert.Equal(t, 1, semver.Compare(b, a), "%s > %s", b, a)
+		assert.Equal(t, 0, semver.Compare(a, a))
+	}
+}
+
+func TestCompare_IgnoresBuild(t *testing.T) {
+	a, err := semver.Parse("v1.0.0+build.1")
+	require.NoError(t, err)
+
+	b, err := semver.Parse("1.0.0+build.2")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, semver.Compare(a, b))
+}
+
+func TestInc(t *testing.T) {
+	tests := map[string]struct {
+		Value string
+		Major string
+		Minor string
+		Patch string
+	}{
+		"release": {
+			Value: "v1.2.3",
+			Major: "v2.0.0",
+			Minor: "v1.3.0",
+			Patch: "v1.2.4",
+		},
+		"major prerelease": {
+			Value: "v2.0.0-rc.1",
+			Major: "v2.0.0",
+			Minor: "v2.0.0",
+			Patch: "v2.0.0",
+		},
+		"patch prerelease": {
+			Value: "1.2.3-beta+exp",
+			Major: "2.0.0",
+			Minor: "1.3.0",
+			Patch: "1.2.3",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := semver.Parse(test.Value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Major, v.IncMajor().String())
+			assert.Equal(t, test.Minor, v.IncMinor().String())
+			assert.Equal(t, test.Patch, v.IncPatch().String())
+		})
+	}
+}